@@ -0,0 +1,17 @@
+data "astra_database_schema" "dev" {
+  database_id  = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+  region       = "us-east1"
+  keyspace     = "puppies"
+  generate_hcl = true
+}
+
+# Write the exported schema to files for review or migration
+resource "local_file" "schema_cql" {
+  content  = data.astra_database_schema.dev.cql
+  filename = "${path.module}/schema.cql"
+}
+
+resource "local_file" "schema_tf" {
+  content  = data.astra_database_schema.dev.hcl
+  filename = "${path.module}/schema.tf.generated"
+}
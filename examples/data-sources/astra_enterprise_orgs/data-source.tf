@@ -0,0 +1,2 @@
+data "astra_enterprise_orgs" "orgs" {
+}
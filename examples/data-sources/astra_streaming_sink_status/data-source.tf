@@ -0,0 +1,14 @@
+data "astra_streaming_sink_status" "sink_status" {
+  cluster     = "pulsar-gcp-useast1"
+  tenant_name = "my-tenant"
+  namespace   = "default"
+  sink_name   = "my-sink"
+}
+
+output "sink_running" {
+  value = data.astra_streaming_sink_status.sink_status.running
+}
+
+output "sink_latest_error" {
+  value = data.astra_streaming_sink_status.sink_status.latest_error
+}
@@ -0,0 +1,6 @@
+data "astra_table" "dev" {
+  database_id = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+  region      = "us-east1"
+  keyspace    = "puppies"
+  table       = "mytable"
+}
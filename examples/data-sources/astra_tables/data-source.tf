@@ -0,0 +1,23 @@
+data "astra_tables" "dev" {
+  database_id = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+  region      = "us-east1"
+  keyspace    = "puppies"
+}
+
+# Enable CDC for every table in the keyspace
+resource "astra_cdc_v3" "cdc" {
+  database_id   = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+  database_name = "my-database"
+  tables = [for t in data.astra_tables.dev.results : {
+    keyspace = "puppies"
+    table    = t.name
+  }]
+  regions = [
+    {
+      region            = "us-east1"
+      datacenter_id     = "f9f4b1e0-4c05-451e-9bba-d631295a7f73-1"
+      streaming_cluster = "pulsar-gcp-useast1"
+      streaming_tenant  = "my-tenant"
+    }
+  ]
+}
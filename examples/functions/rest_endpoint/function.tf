@@ -0,0 +1,11 @@
+data "astra_database" "example_db" {
+  # ...
+}
+
+locals {
+  # If the database has multiple regions, you can specify the desired one explicitly
+  rest_endpoint1 = provider::astra::rest_endpoint(data.astra_database.example_db, "us-central1")
+
+  # Or, if the database has only one region, you can omit the second argument
+  rest_endpoint2 = provider::astra::rest_endpoint(data.astra_database.example_db)
+}
@@ -0,0 +1,2 @@
+# the import id includes the database_id and the address.
+terraform import astra_access_list_entry.app a6bc9c26-e7ce-424f-84c7-0a00afb12588/192.0.2.0/24
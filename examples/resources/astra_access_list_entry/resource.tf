@@ -0,0 +1,9 @@
+resource "astra_access_list_entry" "app" {
+  # Required
+  database_id = "a6bc9c26-e7ce-424f-84c7-0a00afb12588"
+  address     = "192.0.2.0/24"
+
+  # Optional
+  description = "Application servers"
+  enabled     = true
+}
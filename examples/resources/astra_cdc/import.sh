@@ -1 +1,2 @@
-terraform import astra_cdc.example databaseId/keyspace/table/tenantName
\ No newline at end of file
+# The ID is in the form database_id/keyspace/table/tenant_name
+terraform import astra_cdc.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/my_keyspace/my_table/my-tenant
@@ -0,0 +1,56 @@
+# Generate a random pet name to avoid naming conflicts
+resource "random_pet" "pet_name" {}
+
+# Create a new vector database
+resource "astra_database" "example_db" {
+  # Required
+  name                = substr("my-database-${random_pet.pet_name.id}", 0, 50)
+  keyspace            = "default_keyspace"
+  cloud_provider      = "gcp"
+  regions             = ["us-east1"]
+  db_type             = "vector"
+  deletion_protection = false
+}
+
+resource "astra_collection" "example_collection" {
+  # Required
+  database_id = astra_database.example_db.id
+  name        = "documents"
+
+  # Optional
+  keyspace = "default_keyspace"
+  region   = "us-east1"
+  vector = {
+    dimension = 1536
+    metric    = "cosine"
+  }
+  indexing = {
+    deny = ["metadata.raw_html"]
+  }
+  default_id_type     = "uuidv7"
+  deletion_protection = true
+}
+
+# Collection with vectors generated server side by an embedding provider (vectorize)
+resource "astra_collection" "example_vectorize" {
+  # Required
+  database_id = astra_database.example_db.id
+  name        = "articles"
+
+  # Optional
+  vector = {
+    dimension = 1536
+    metric    = "dot_product"
+    service = {
+      provider   = "openai"
+      model_name = "text-embedding-3-small"
+      authentication = {
+        providerKey = "my-openai-shared-secret"
+      }
+      parameters = {
+        organizationId = "org-example"
+      }
+    }
+  }
+  deletion_protection = true
+}
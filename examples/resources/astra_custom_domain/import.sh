@@ -0,0 +1,2 @@
+# the import id includes the database_id, datacenter_id and domain.
+terraform import astra_custom_domain.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/48bfc13b-c1a5-48db-b70f-b6ef9709872b-1/db.example.com
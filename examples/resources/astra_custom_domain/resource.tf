@@ -0,0 +1,22 @@
+resource "astra_custom_domain" "example" {
+  # Required
+  database_id   = "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+  datacenter_id = "48bfc13b-c1a5-48db-b70f-b6ef9709872b-1"
+  domain        = "db.example.com"
+
+  # Optional
+  # Set to false to get the DNS records to create before the domain is validated
+  wait_for_validation = true
+}
+
+# Create the DNS records with your DNS provider
+output "custom_domain_dns_records" {
+  value = astra_custom_domain.example.dns_records
+}
+
+# Secure connect bundle for the custom domain
+data "astra_secure_connect_bundle_url" "example" {
+  database_id   = astra_custom_domain.example.database_id
+  datacenter_id = astra_custom_domain.example.datacenter_id
+  custom_domain = astra_custom_domain.example.domain
+}
@@ -2,12 +2,27 @@
 resource "astra_customer_key" "customerkey" {
   cloud_provider = "aws"
   region         = "us-east-1"
-  key_id         = "arn:aws:kms:us-east-1:123456789012:key/1a2b3c4d-5e6f-1a2b-3c4d-5e6f1a2b3c4d"
+  aws {
+    key_arn = "arn:aws:kms:us-east-1:123456789012:key/1a2b3c4d-5e6f-1a2b-3c4d-5e6f1a2b3c4d"
+  }
 }
 
 # GCP example
 resource "astra_customer_key" "customerKey" {
   cloud_provider = "gcp"
   region         = "us-east1"
-  key_id         = "projects/my-project/locations/us-east1/keyRings/my-key-ring/cryptoKeys/my-key"
-}
\ No newline at end of file
+  gcp {
+    key_name = "projects/my-project/locations/us-east1/keyRings/my-key-ring/cryptoKeys/my-key"
+  }
+}
+
+# Azure example
+resource "astra_customer_key" "azureCustomerKey" {
+  cloud_provider = "azure"
+  region         = "eastus"
+  azure {
+    vault_uri = "https://my-vault.vault.azure.net"
+    key_name  = "my-key"
+    tenant_id = "9a8b7c6d-5e4f-3a2b-1c0d-9e8f7a6b5c4d"
+  }
+}
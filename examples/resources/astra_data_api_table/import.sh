@@ -0,0 +1,2 @@
+# the import id includes the database_id, the keyspace name and the table name.
+terraform import astra_data_api_table.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/default_keyspace/products
@@ -0,0 +1,54 @@
+# Generate a random pet name to avoid naming conflicts
+resource "random_pet" "pet_name" {}
+
+# Create a new vector database
+resource "astra_database" "example_db" {
+  # Required
+  name                = substr("my-database-${random_pet.pet_name.id}", 0, 50)
+  keyspace            = "default_keyspace"
+  cloud_provider      = "gcp"
+  regions             = ["us-east1"]
+  db_type             = "vector"
+  deletion_protection = false
+}
+
+resource "astra_data_api_table" "example_table" {
+  # Required
+  database_id = astra_database.example_db.id
+  name        = "products"
+  columns = {
+    id = {
+      type = "text"
+    }
+    created_at = {
+      type = "timestamp"
+    }
+    attributes = {
+      type       = "map"
+      key_type   = "text"
+      value_type = "text"
+    }
+    description = {
+      type = "text"
+    }
+    # vectors generated server side from the text inserted in the column
+    description_embedding = {
+      type = "vector"
+      service = {
+        provider   = "nvidia"
+        model_name = "NV-Embed-QA"
+      }
+    }
+  }
+  primary_key = {
+    partition_by = ["id"]
+    partition_sort = [
+      { column = "created_at", order = "desc" },
+    ]
+  }
+
+  # Optional
+  keyspace            = "default_keyspace"
+  region              = "us-east1"
+  deletion_protection = true
+}
@@ -0,0 +1,2 @@
+# the import id is the database_id.
+terraform import astra_database_telemetry.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b
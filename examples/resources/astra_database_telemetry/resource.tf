@@ -0,0 +1,20 @@
+variable "prometheus_token" {
+  type      = string
+  sensitive = true
+}
+
+resource "astra_database_telemetry" "example" {
+  # Required
+  database_id = "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+
+  # At least one destination
+  prometheus_remote = {
+    endpoint      = "https://prometheus.example.com/api/v1/write"
+    auth_strategy = "bearer"
+    token         = var.prometheus_token
+  }
+
+  # Optional
+  # Increment to send updated credentials, changes to write-only attributes are not detected
+  credentials_version = 1
+}
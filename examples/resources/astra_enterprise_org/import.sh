@@ -1,3 +1,2 @@
-**NOTE** Import of astra_enterprise_org resources is currently not available, and
-has not been available in previous versions of the provider, up to and including
-version 2.3.12.
\ No newline at end of file
+# the import id is the organization_id.
+terraform import astra_enterprise_org.entorg a1b2c3d4-5e6f-7a8b-9c0d-1e2f3a4b5c6d
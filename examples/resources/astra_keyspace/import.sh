@@ -1,2 +1,2 @@
 # the import id includes the database_id and the keyspace name.
-terraform import astra_keyspace.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/keyspace/example
\ No newline at end of file
+terraform import astra_keyspace.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/example
@@ -15,4 +15,8 @@ resource "astra_keyspace" "example_keyspace" {
   # Required
   database_id = astra_database.example_db.id
   name        = "example_keyspace_2"
+
+  # Optional
+  deletion_protection = true
+  fail_if_not_empty   = true
 }
@@ -0,0 +1,2 @@
+# The ID is in the form cluster_name/tenant_name/namespace/function_name
+terraform import astra_streaming_function.example pulsar-gcp-uscentral1/my-tenant/default/drop-password
@@ -0,0 +1,73 @@
+# Generate a random pet name to avoid naming conflicts
+resource "random_pet" "server" {}
+
+# Create a new tenant
+resource "astra_streaming_tenant" "streaming_tenant" {
+  tenant_name         = "my-tenant-${random_pet.server.id}"
+  user_email          = "someuser@example.com"
+  cloud_provider      = "gcp"
+  deletion_protection = false
+  region              = "us-central1"
+}
+
+# Create the input and output topics
+resource "astra_streaming_topic" "input" {
+  cluster             = astra_streaming_tenant.streaming_tenant.cluster_name
+  tenant              = astra_streaming_tenant.streaming_tenant.tenant_name
+  namespace           = "default"
+  topic               = "users"
+  deletion_protection = false
+}
+
+resource "astra_streaming_topic" "output" {
+  cluster             = astra_streaming_tenant.streaming_tenant.cluster_name
+  tenant              = astra_streaming_tenant.streaming_tenant.tenant_name
+  namespace           = "default"
+  topic               = "users-public"
+  deletion_protection = false
+}
+
+# Deploy a transform function
+# Refer to Astra Streaming documentation for more information on transform functions
+#   https://docs.datastax.com/en/streaming/streaming-learning/functions/index.html
+resource "astra_streaming_function" "transform" {
+  # Required
+  cluster       = astra_streaming_tenant.streaming_tenant.cluster_name
+  tenant_name   = astra_streaming_tenant.streaming_tenant.tenant_name
+  namespace     = "default"
+  function_name = "drop-password"
+  archive       = "builtin://transforms"
+  inputs        = [astra_streaming_topic.input.topic_fqn]
+  parallelism   = 1
+
+  # Optional
+  class_name = "com.datastax.oss.pulsar.functions.transforms.TransformFunction"
+  output     = astra_streaming_topic.output.topic_fqn
+  user_config = jsonencode({
+    "steps" : [
+      { "type" : "drop-fields", "fields" : "password" }
+    ]
+  })
+
+  deletion_protection = false
+}
+
+# Deploy a function from a local package, the function is redeployed when the file changes
+resource "astra_streaming_function" "custom" {
+  cluster       = astra_streaming_tenant.streaming_tenant.cluster_name
+  tenant_name   = astra_streaming_tenant.streaming_tenant.tenant_name
+  namespace     = "default"
+  function_name = "enrich-users"
+  package_path  = "${path.module}/functions/enrich-users.jar"
+  class_name    = "com.example.EnrichUsersFunction"
+  inputs        = [astra_streaming_topic.input.topic_fqn]
+  log_topic     = "persistent://${astra_streaming_tenant.streaming_tenant.tenant_name}/default/enrich-users-log"
+  parallelism   = 2
+
+  resources = {
+    cpu = 0.5
+    ram = 1073741824
+  }
+
+  deletion_protection = false
+}
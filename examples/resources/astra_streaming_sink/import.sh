@@ -1 +1,3 @@
-terraform import astra_streaming_sink.example tenant_name/topic
\ No newline at end of file
+# The ID is in the form cluster_name/tenant_name/namespace/sink_name. The sink may be configured with `cluster` or with
+# the deprecated `cloud_provider` and `region`, the settings left to the Pulsar defaults are not imported.
+terraform import astra_streaming_sink.example pulsar-gcp-uscentral1/my-tenant/default/clickhouse-sink
@@ -52,5 +52,42 @@ resource "astra_streaming_sink" "streaming_sink" {
   deletion_protection = false
 }
 
+# Create a sink consuming from several topics, with a dead letter topic and dedicated resources
+resource "astra_streaming_sink" "multi_input_sink" {
+  cluster               = astra_streaming_tenant.streaming_tenant.cluster_name
+  tenant_name           = astra_streaming_tenant.streaming_tenant.tenant_name
+  namespace             = astra_streaming_namespace.streaming_namespace.namespace
+  sink_name             = "sink2"
+  archive               = "builtin://jdbc-clickhouse"
+  inputs                = [astra_streaming_topic.streaming_topic.topic_fqn]
+  auto_ack              = true
+  parallelism           = 2
+  retain_ordering       = false
+  processing_guarantees = "ATLEAST_ONCE"
+  sink_configs = jsonencode({
+    "userName" : "clickhouse",
+    "password" : "password",
+    "jdbcUrl" : "jdbc:clickhouse://fake.clickhouse.url:8123/pulsar_clickhouse_jdbc_sink",
+    "tableName" : "pulsar_clickhouse_jdbc_sink"
+  })
+
+  input_specs = {
+    (astra_streaming_topic.streaming_topic.topic_fqn) = {
+      schema_type = "AVRO"
+    }
+  }
+  subscription_name     = "clickhouse-sink"
+  subscription_position = "Earliest"
+  max_message_retries   = 5
+  dead_letter_topic     = "persistent://${astra_streaming_tenant.streaming_tenant.tenant_name}/my-namespace/my-topic-dlq"
+
+  resources = {
+    cpu = 0.5
+    ram = 1073741824
+  }
+
+  deletion_protection = false
+}
+
 # --Formatted Outputs--
 # astra_streaming_topic.streaming_sink.id
@@ -0,0 +1,50 @@
+# Generate a random pet name to avoid naming conflicts
+resource "random_pet" "server" {}
+
+# Create a new tenant
+resource "astra_streaming_tenant" "streaming_tenant" {
+  tenant_name         = "my-tenant-${random_pet.server.id}"
+  user_email          = "someuser@example.com"
+  cloud_provider      = "gcp"
+  deletion_protection = false
+  region              = "us-central1"
+}
+
+# Create a new topic
+resource "astra_streaming_topic" "streaming_topic" {
+  cluster             = astra_streaming_tenant.streaming_tenant.cluster_name
+  tenant              = astra_streaming_tenant.streaming_tenant.tenant_name
+  namespace           = "default"
+  topic               = "my-topic"
+  deletion_protection = false
+}
+
+# Create a new source
+# Refer to Astra Streaming documentation for more information on sources
+#   https://docs.datastax.com/en/streaming/streaming-learning/pulsar-io/connectors/index.html
+resource "astra_streaming_source" "streaming_source" {
+  # Required
+  cluster     = astra_streaming_tenant.streaming_tenant.cluster_name
+  tenant_name = astra_streaming_tenant.streaming_tenant.tenant_name
+  namespace   = "default"
+  source_name = "kafka-bridge"
+  archive     = "builtin://kafka"
+  topic       = astra_streaming_topic.streaming_topic.topic_fqn
+  parallelism = 1
+  source_configs = jsonencode({
+    "bootstrapServers" : "kafka.example.com:9092",
+    "groupId" : "astra-streaming",
+    "topic" : "orders"
+  })
+
+  # Optional
+  schema_type           = "STRING"
+  processing_guarantees = "ATLEAST_ONCE"
+
+  resources = {
+    cpu = 0.5
+    ram = 1073741824
+  }
+
+  deletion_protection = false
+}
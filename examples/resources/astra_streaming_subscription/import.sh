@@ -0,0 +1,2 @@
+# The ID is in the form cluster_name/tenant_name/namespace/topic_name/subscription_name
+terraform import astra_streaming_subscription.example pulsar-gcp-uscentral1/my-tenant/default/orders/order-processor
@@ -0,0 +1,19 @@
+resource "astra_streaming_topic" "orders" {
+  cluster   = "pulsar-gcp-uscentral1"
+  tenant    = "my-tenant"
+  namespace = "default"
+  topic     = "orders"
+}
+
+# Create a durable subscription before the consumers start
+resource "astra_streaming_subscription" "order_processor" {
+  cluster           = "pulsar-gcp-uscentral1"
+  topic_fqn         = astra_streaming_topic.orders.topic_fqn
+  subscription_name = "order-processor"
+  initial_position  = "earliest"
+
+  # Changing this value resets the cursor of the subscription, for example to replay messages after an incident
+  reset_to = "2024-01-02T15:04:05Z"
+
+  deletion_protection = false
+}
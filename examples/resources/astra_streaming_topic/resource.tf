@@ -32,6 +32,20 @@ resource "astra_streaming_topic" "streaming_topic" {
   num_partitions      = 2
   partitioned         = true
   persistent          = true
+
+  # Override the namespace policies for this topic
+  policies = {
+    message_ttl_in_seconds = 3600
+    max_consumers          = 10
+    retention_policies = {
+      retention_time_in_minutes = 1440
+      retention_size_in_mb      = 1024
+    }
+    publish_rate = {
+      publish_throttling_rate_in_msg  = 1000
+      publish_throttling_rate_in_byte = 1048576
+    }
+  }
 }
 
 # --Formatted Outputs--
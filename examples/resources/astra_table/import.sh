@@ -1,2 +1,2 @@
 # the import id includes the database_id, keyspace name, and table name.
-terraform import astra_table.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/us-central1/keyspacename/tablename
+terraform import astra_table.example 48bfc13b-c1a5-48db-b70f-b6ef9709872b/keyspacename/tablename
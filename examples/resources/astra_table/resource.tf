@@ -52,4 +52,8 @@ resource "astra_table" "example_table" {
       TypeDefinition : "text"
     }
   ]
+
+  # Optional
+  deletion_protection = true
+  fail_if_not_empty   = true
 }
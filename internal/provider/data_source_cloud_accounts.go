@@ -21,7 +21,7 @@ func dataSourceCloudAccounts() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			// Required inputs
 			"cloud_provider": {
-				Description:      "The cloud provider where the Customer Key exists (Currently supported: aws, gcp)",
+				Description:      "The cloud provider where the Customer Key exists (Currently supported: aws, gcp, azure)",
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
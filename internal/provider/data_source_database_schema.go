@@ -0,0 +1,422 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDatabaseSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_database_schema` provides a datasource that exports the schema of an Astra database, or of a single keyspace, as CQL DDL statements. " +
+			"It can optionally generate the equivalent `astra_keyspace` and `astra_table` Terraform configuration, along with `import` blocks, to bring an existing schema under Terraform management.",
+
+		ReadContext: dataSourceDatabaseSchemaRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"region": {
+				Description: "The region of the database used to access the schema endpoints.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+
+			// Optional
+			"keyspace": {
+				Description:      "Only export the schema of this keyspace. If omitted, all keyspaces of the database are exported.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			"generate_hcl": {
+				Description: "Whether to also generate the equivalent Terraform configuration in the `hcl` attribute. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			// Computed
+			"keyspaces": {
+				Description: "The names of the exported keyspaces.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"cql": {
+				Description: "The CQL DDL (`CREATE KEYSPACE`, `CREATE TYPE`, `CREATE TABLE` and `CREATE INDEX` statements) for the exported schema.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"hcl": {
+				Description: "The Terraform configuration for the exported schema, including `import` blocks for the existing objects. Only set when `generate_hcl` is `true`. " +
+					"Secondary indexes have no Terraform resource and are emitted as comments.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDatabaseSchemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	restClients := meta.(astraClients).restClientPool
+	token := meta.(astraClients).token
+
+	databaseID := d.Get("database_id").(string)
+	region := d.Get("region").(string)
+
+	keyspaceNames := []string{}
+	if k := d.Get("keyspace").(string); k != "" {
+		keyspaceNames = append(keyspaceNames, k)
+	} else {
+		keyspaces, err := listKeyspaces(ctx, client, databaseID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		keyspaceNames = append(keyspaceNames, keyspaces...)
+		sort.Strings(keyspaceNames)
+	}
+
+	restClient, _, err := restClients.get(ctx, databaseID, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var keyspaces []keyspaceSchema
+	for _, k := range keyspaceNames {
+		ks, err := getKeyspaceSchema(ctx, restClient, token, k)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		keyspaces = append(keyspaces, *ks)
+	}
+
+	cql := make([]string, 0, len(keyspaces))
+	for _, ks := range keyspaces {
+		cql = append(cql, keyspaceSchemaToCQL(ks))
+	}
+
+	if k := d.Get("keyspace").(string); k != "" {
+		d.SetId(fmt.Sprintf("%s/keyspace/%s", databaseID, k))
+	} else {
+		d.SetId(databaseID)
+	}
+	if err := d.Set("keyspaces", keyspaceNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("cql", strings.Join(cql, "\n")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hcl := ""
+	if d.Get("generate_hcl").(bool) {
+		defaultKeyspace, err := getDefaultKeyspace(ctx, client, databaseID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		blocks := make([]string, 0, len(keyspaces))
+		for _, ks := range keyspaces {
+			blocks = append(blocks, keyspaceSchemaToHCL(databaseID, region, ks, ks.Name == defaultKeyspace))
+		}
+		hcl = strings.Join(blocks, "\n")
+	}
+	if err := d.Set("hcl", hcl); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// keyspaceSchema holds the schema metadata of a keyspace as returned by the Stargate REST schema endpoints.
+type keyspaceSchema struct {
+	Name        string
+	Datacenters []astrarestapi.Datacenter
+	Types       []astrarestapi.TypeResponse
+	Tables      []tableSchema
+}
+
+type tableSchema struct {
+	astrarestapi.Table
+	Indexes []restIndex
+}
+
+func getKeyspaceSchema(ctx context.Context, restClient *astrarestapi.ClientWithResponses, token, keyspaceName string) (*keyspaceSchema, error) {
+	raw := true
+	ksResp, err := restClient.GetKeyspaceWithResponse(ctx, keyspaceName, &astrarestapi.GetKeyspaceParams{
+		Raw:             &raw,
+		XCassandraToken: token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting keyspace %s: %w", keyspaceName, err)
+	} else if ksResp.StatusCode() >= 400 || ksResp.JSON200 == nil {
+		return nil, fmt.Errorf("error getting keyspace %s, status code: %d, message: %s", keyspaceName, ksResp.StatusCode(), string(ksResp.Body))
+	}
+	ks := &keyspaceSchema{
+		Name: keyspaceName,
+	}
+	if ksResp.JSON200.Datacenters != nil {
+		ks.Datacenters = *ksResp.JSON200.Datacenters
+	}
+
+	typesResp, err := restClient.GetTypesWithResponse(ctx, keyspaceName, &astrarestapi.GetTypesParams{
+		Raw:             &raw,
+		XCassandraToken: token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting user defined types of keyspace %s: %w", keyspaceName, err)
+	} else if typesResp.StatusCode() >= 400 {
+		return nil, fmt.Errorf("error getting user defined types of keyspace %s, status code: %d, message: %s", keyspaceName, typesResp.StatusCode(), string(typesResp.Body))
+	}
+	if typesResp.JSON200 != nil {
+		ks.Types = *typesResp.JSON200
+	}
+
+	tables, err := listTables(ctx, restClient, token, keyspaceName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+	for _, t := range tables {
+		indexes, err := listTableIndexes(ctx, restClient, token, keyspaceName, t.Name)
+		if err != nil {
+			return nil, err
+		}
+		ks.Tables = append(ks.Tables, tableSchema{Table: t, Indexes: indexes})
+	}
+
+	return ks, nil
+}
+
+// getDefaultKeyspace returns the keyspace which was created along with the database.  It is managed by the
+// astra_database resource, so no astra_keyspace configuration is generated for it.
+func getDefaultKeyspace(ctx context.Context, client *astra.ClientWithResponses, databaseID string) (string, error) {
+	resp, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return "", err
+	}
+	if resp.JSON200 == nil {
+		return "", fmt.Errorf("error fetching database: %s", string(resp.Body))
+	}
+	return astra.StringValue(resp.JSON200.Info.Keyspace), nil
+}
+
+var cqlUnquotedIdentifierRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// cqlIdentifier quotes a CQL identifier if it would otherwise be case-folded or is not a valid unquoted identifier.
+func cqlIdentifier(name string) string {
+	if cqlUnquotedIdentifierRegex.MatchString(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func cqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func keyspaceSchemaToCQL(ks keyspaceSchema) string {
+	var b strings.Builder
+	keyspace := cqlIdentifier(ks.Name)
+
+	replication := []string{cqlString("class") + ": " + cqlString("NetworkTopologyStrategy")}
+	for _, dc := range ks.Datacenters {
+		replication = append(replication, fmt.Sprintf("%s: %d", cqlString(dc.Name), dc.Replicas))
+	}
+	fmt.Fprintf(&b, "CREATE KEYSPACE IF NOT EXISTS %s WITH replication = {%s};\n", keyspace, strings.Join(replication, ", "))
+
+	for _, t := range ks.Types {
+		fields := []string{}
+		if t.Fields != nil {
+			for _, f := range *t.Fields {
+				fields = append(fields, fmt.Sprintf("    %s %s", cqlIdentifier(f.Name), f.TypeDefinition))
+			}
+		}
+		fmt.Fprintf(&b, "\nCREATE TYPE IF NOT EXISTS %s.%s (\n%s\n);\n", keyspace, cqlIdentifier(astra.StringValue(t.Name)), strings.Join(fields, ",\n"))
+	}
+
+	for _, t := range ks.Tables {
+		b.WriteString("\n")
+		b.WriteString(tableSchemaToCQL(ks.Name, t))
+	}
+	return b.String()
+}
+
+func tableSchemaToCQL(keyspaceName string, t tableSchema) string {
+	var b strings.Builder
+	table := cqlIdentifier(keyspaceName) + "." + cqlIdentifier(t.Name)
+
+	lines := []string{}
+	for _, c := range t.ColumnDefinitions {
+		line := fmt.Sprintf("    %s %s", cqlIdentifier(c.Name), c.TypeDefinition)
+		if c.Static != nil && *c.Static {
+			line += " static"
+		}
+		lines = append(lines, line)
+	}
+
+	partitionKey := cqlIdentifierList(t.PrimaryKey.PartitionKey)
+	if len(t.PrimaryKey.PartitionKey) > 1 {
+		partitionKey = "(" + partitionKey + ")"
+	}
+	primaryKey := []string{partitionKey}
+	if t.PrimaryKey.ClusteringKey != nil && len(*t.PrimaryKey.ClusteringKey) > 0 {
+		primaryKey = append(primaryKey, cqlIdentifierList(*t.PrimaryKey.ClusteringKey))
+	}
+	lines = append(lines, fmt.Sprintf("    PRIMARY KEY (%s)", strings.Join(primaryKey, ", ")))
+
+	fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (\n%s\n)", table, strings.Join(lines, ",\n"))
+
+	options := []string{}
+	if t.TableOptions != nil {
+		if t.TableOptions.ClusteringExpression != nil && len(*t.TableOptions.ClusteringExpression) > 0 {
+			order := []string{}
+			for _, c := range *t.TableOptions.ClusteringExpression {
+				order = append(order, fmt.Sprintf("%s %s", cqlIdentifier(c.Column), c.Order))
+			}
+			options = append(options, fmt.Sprintf("CLUSTERING ORDER BY (%s)", strings.Join(order, ", ")))
+		}
+		if t.TableOptions.DefaultTimeToLive != nil && *t.TableOptions.DefaultTimeToLive > 0 {
+			options = append(options, fmt.Sprintf("default_time_to_live = %d", *t.TableOptions.DefaultTimeToLive))
+		}
+	}
+	if len(options) > 0 {
+		fmt.Fprintf(&b, " WITH %s", strings.Join(options, "\n    AND "))
+	}
+	b.WriteString(";\n")
+
+	for _, idx := range t.Indexes {
+		b.WriteString(indexToCQL(table, idx))
+	}
+	return b.String()
+}
+
+func indexToCQL(table string, idx restIndex) string {
+	target := ""
+	className := ""
+	otherOptions := map[string]string{}
+	for _, o := range idx.Options {
+		key := astra.StringValue(o.Key)
+		switch key {
+		case "target":
+			target = astra.StringValue(o.Value)
+		case "class_name":
+			className = astra.StringValue(o.Value)
+		default:
+			otherOptions[key] = astra.StringValue(o.Value)
+		}
+	}
+
+	if idx.Kind != "CUSTOM" {
+		return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s);\n", cqlIdentifier(idx.IndexName), table, target)
+	}
+
+	stmt := fmt.Sprintf("CREATE CUSTOM INDEX IF NOT EXISTS %s ON %s (%s) USING %s", cqlIdentifier(idx.IndexName), table, target, cqlString(className))
+	if len(otherOptions) > 0 {
+		keys := make([]string, 0, len(otherOptions))
+		for k := range otherOptions {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		opts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			opts = append(opts, fmt.Sprintf("%s: %s", cqlString(k), cqlString(otherOptions[k])))
+		}
+		stmt += fmt.Sprintf(" WITH OPTIONS = {%s}", strings.Join(opts, ", "))
+	}
+	return stmt + ";\n"
+}
+
+func cqlIdentifierList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = cqlIdentifier(n)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+var hclInvalidNameCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// hclResourceName builds a valid Terraform resource name from the given parts.
+func hclResourceName(parts ...string) string {
+	name := hclInvalidNameCharsRegex.ReplaceAllString(strings.Join(parts, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return strings.ToLower(name)
+}
+
+func keyspaceSchemaToHCL(databaseID, region string, ks keyspaceSchema, isDefaultKeyspace bool) string {
+	var b strings.Builder
+	keyspaceRef := fmt.Sprintf("%q", ks.Name)
+
+	if isDefaultKeyspace {
+		fmt.Fprintf(&b, "# Keyspace %q is the initial keyspace of the database and is managed by the astra_database resource.\n", ks.Name)
+	} else {
+		name := hclResourceName(ks.Name)
+		fmt.Fprintf(&b, "import {\n  to = astra_keyspace.%s\n  id = %q\n}\n\n", name, keyspaceID(databaseID, ks.Name))
+		fmt.Fprintf(&b, "resource \"astra_keyspace\" %q {\n  name        = %q\n  database_id = %q\n}\n", name, ks.Name, databaseID)
+		keyspaceRef = fmt.Sprintf("astra_keyspace.%s.name", name)
+	}
+
+	for _, t := range ks.Types {
+		fmt.Fprintf(&b, "\n# User defined type %s.%s has no Terraform resource and must be created with CQL.\n", ks.Name, astra.StringValue(t.Name))
+	}
+
+	for _, t := range ks.Tables {
+		b.WriteString("\n")
+		b.WriteString(tableSchemaToHCL(databaseID, region, ks.Name, keyspaceRef, t))
+	}
+	return b.String()
+}
+
+func tableSchemaToHCL(databaseID, region, keyspaceName, keyspaceRef string, t tableSchema) string {
+	var b strings.Builder
+	name := hclResourceName(keyspaceName, t.Name)
+
+	fmt.Fprintf(&b, "import {\n  to = astra_table.%s\n  id = %q\n}\n\n", name, tableID(databaseID, keyspaceName, t.Name))
+	fmt.Fprintf(&b, "resource \"astra_table\" %q {\n", name)
+	fmt.Fprintf(&b, "  database_id        = %q\n", databaseID)
+	fmt.Fprintf(&b, "  region             = %q\n", region)
+	fmt.Fprintf(&b, "  keyspace           = %s\n", keyspaceRef)
+	fmt.Fprintf(&b, "  table              = %q\n", t.Name)
+	fmt.Fprintf(&b, "  partition_keys     = %q\n", strings.Join(t.PrimaryKey.PartitionKey, ":"))
+	if t.PrimaryKey.ClusteringKey != nil && len(*t.PrimaryKey.ClusteringKey) > 0 {
+		fmt.Fprintf(&b, "  clustering_columns = %q\n", strings.Join(*t.PrimaryKey.ClusteringKey, ":"))
+	}
+	// Columns are sorted by name to match the order astra_table stores them in after an import.
+	columns := make([]astrarestapi.ColumnDefinition, len(t.ColumnDefinitions))
+	copy(columns, t.ColumnDefinitions)
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Name < columns[j].Name
+	})
+	b.WriteString("  column_definitions = [\n")
+	for _, c := range columns {
+		static := c.Static != nil && *c.Static
+		fmt.Fprintf(&b, "    {\n      Name           = %q\n      Static         = %t\n      TypeDefinition = %q\n    },\n", c.Name, static, string(c.TypeDefinition))
+	}
+	b.WriteString("  ]\n}\n")
+
+	for _, idx := range t.Indexes {
+		fmt.Fprintf(&b, "\n# Index %s has no Terraform resource and must be created with CQL:\n# %s", idx.IndexName,
+			indexToCQL(cqlIdentifier(keyspaceName)+"."+cqlIdentifier(t.Name), idx))
+	}
+	return b.String()
+}
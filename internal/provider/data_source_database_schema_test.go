@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyspaceSchemaToCQL(t *testing.T) {
+	static := true
+	ttl := 3600
+	ks := keyspaceSchema{
+		Name:        "shop",
+		Datacenters: []astrarestapi.Datacenter{{Name: "dc-1", Replicas: 3}},
+		Types: []astrarestapi.TypeResponse{{
+			Name:   astra.StringPtr("address"),
+			Fields: &[]astrarestapi.TypeField{{Name: "street", TypeDefinition: "text"}, {Name: "zip", TypeDefinition: "int"}},
+		}},
+		Tables: []tableSchema{{
+			Table: astrarestapi.Table{
+				Name: "Orders",
+				ColumnDefinitions: []astrarestapi.ColumnDefinition{
+					{Name: "customer", TypeDefinition: "uuid"},
+					{Name: "day", TypeDefinition: "date"},
+					{Name: "ts", TypeDefinition: "timestamp"},
+					{Name: "total", TypeDefinition: "decimal"},
+					{Name: "region", TypeDefinition: "text", Static: &static},
+				},
+				PrimaryKey: astrarestapi.PrimaryKey{
+					PartitionKey:  []string{"customer", "day"},
+					ClusteringKey: &[]string{"ts"},
+				},
+				TableOptions: &astrarestapi.TableOptions{
+					ClusteringExpression: &[]astrarestapi.ClusteringExpression{{Column: "ts", Order: astrarestapi.DESC}},
+					DefaultTimeToLive:    &ttl,
+				},
+			},
+			Indexes: []restIndex{
+				{IndexName: "orders_total_idx", Kind: "COMPOSITES", Options: []astrarestapi.IndexOptions{{Key: astra.StringPtr("target"), Value: astra.StringPtr("total")}}},
+				{IndexName: "orders_region_sai", Kind: "CUSTOM", Options: []astrarestapi.IndexOptions{
+					{Key: astra.StringPtr("target"), Value: astra.StringPtr("region")},
+					{Key: astra.StringPtr("class_name"), Value: astra.StringPtr("StorageAttachedIndex")},
+					{Key: astra.StringPtr("case_sensitive"), Value: astra.StringPtr("false")},
+				}},
+			},
+		}},
+	}
+
+	expected := `CREATE KEYSPACE IF NOT EXISTS shop WITH replication = {'class': 'NetworkTopologyStrategy', 'dc-1': 3};
+
+CREATE TYPE IF NOT EXISTS shop.address (
+    street text,
+    zip int
+);
+
+CREATE TABLE IF NOT EXISTS shop."Orders" (
+    customer uuid,
+    day date,
+    ts timestamp,
+    total decimal,
+    region text static,
+    PRIMARY KEY ((customer, day), ts)
+) WITH CLUSTERING ORDER BY (ts DESC)
+    AND default_time_to_live = 3600;
+CREATE INDEX IF NOT EXISTS orders_total_idx ON shop."Orders" (total);
+CREATE CUSTOM INDEX IF NOT EXISTS orders_region_sai ON shop."Orders" (region) USING 'StorageAttachedIndex' WITH OPTIONS = {'case_sensitive': 'false'};
+`
+	assert.Equal(t, expected, keyspaceSchemaToCQL(ks))
+}
+
+func TestTableSchemaToHCL(t *testing.T) {
+	table := tableSchema{
+		Table: astrarestapi.Table{
+			Name: "users",
+			ColumnDefinitions: []astrarestapi.ColumnDefinition{
+				{Name: "id", TypeDefinition: "uuid"},
+				{Name: "email", TypeDefinition: "text"},
+			},
+			PrimaryKey: astrarestapi.PrimaryKey{
+				PartitionKey: []string{"id"},
+			},
+		},
+	}
+
+	expected := `import {
+  to = astra_table.app_users
+  id = "f9f4b1e0-4c05-451e-9bba-d631295a7f73/app/users"
+}
+
+resource "astra_table" "app_users" {
+  database_id        = "f9f4b1e0-4c05-451e-9bba-d631295a7f73"
+  region             = "us-east1"
+  keyspace           = astra_keyspace.app.name
+  table              = "users"
+  partition_keys     = "id"
+  column_definitions = [
+    {
+      Name           = "email"
+      Static         = false
+      TypeDefinition = "text"
+    },
+    {
+      Name           = "id"
+      Static         = false
+      TypeDefinition = "uuid"
+    },
+  ]
+}
+`
+	assert.Equal(t, expected, tableSchemaToHCL("f9f4b1e0-4c05-451e-9bba-d631295a7f73", "us-east1", "app", "astra_keyspace.app.name", table))
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceEnterpriseOrgs() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_enterprise_orgs` provides a datasource for the list of Organizations of the Enterprise of the current Astra Organization.",
+
+		ReadContext: dataSourceEnterpriseOrgsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			// Computed
+			"results": {
+				Type:        schema.TypeList,
+				Description: "The list of Enterprise organizations.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"organization_id": {
+							Description: "The Astra organization ID (UUID).",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Organization name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"email": {
+							Description: "Organization email address",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"enterprise_id": {
+							Description: "UUID of the Enterprise of the organization",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"organization_type": {
+							Description: "The type of the organization.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"organization_group_id": {
+							Description: "The group ID (UUID) of the organization.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "The timestamp when the organization was created.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_modified": {
+							Description: "The timestamp when the organization was last modified.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEnterpriseOrgsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	orgList, err := listEnterpriseOrgs(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	orgs := make([]map[string]interface{}, 0, len(orgList))
+	for _, org := range orgList {
+		orgs = append(orgs, flattenEnterpriseOrg(org))
+	}
+	d.SetId(id.UniqueId())
+	if err := d.Set("results", orgs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnterpriseOrgsDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_ENTERPRISE_TEST_ENABLED")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "astra_enterprise_orgs" "orgs" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.astra_enterprise_orgs.orgs", "results.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenEnterpriseOrg(t *testing.T) {
+	org := flattenEnterpriseOrg(astra.CreateOrgInEnterpriseResponse{
+		OrganizationID:      astra.StringPtr("a1b2c3d4-5e6f-7a8b-9c0d-1e2f3a4b5c6d"),
+		OrganizationName:    astra.StringPtr("My Enterprise Organization"),
+		OrganizationGroupId: astra.StringPtr("b1b2c3d4-5e6f-7a8b-9c0d-1e2f3a4b5c6d"),
+	})
+	assert.Equal(t, "a1b2c3d4-5e6f-7a8b-9c0d-1e2f3a4b5c6d", org["organization_id"])
+	assert.Equal(t, "My Enterprise Organization", org["name"])
+	assert.Equal(t, "b1b2c3d4-5e6f-7a8b-9c0d-1e2f3a4b5c6d", org["organization_group_id"])
+	assert.Equal(t, "", org["email"])
+}
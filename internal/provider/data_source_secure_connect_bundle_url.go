@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -32,6 +33,11 @@ func dataSourceSecureConnectBundleURL() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"custom_domain": {
+				Description: "A custom domain, usually the `domain` of an `astra_custom_domain` resource. If set, only the custom domain bundles of this domain are returned, and an error is returned if there are none.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
 			// Computed
 			"secure_bundles": {
 				Description: "A list of Secure Connect Bundle info",
@@ -114,12 +120,15 @@ func dataSourceSecureConnectBundleURLRead(ctx context.Context, d *schema.Resourc
 	if datacenterID != "" {
 		tflog.Debug(ctx, fmt.Sprintf("Datacenter ID %s was specified, will filter later\n", datacenterID))
 	}
+	customDomain := d.Get("custom_domain").(string)
 
 	creds, err := getSecureConnectBundles(ctx, client, databaseID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	setSecureConnectBundleData(ctx, d, databaseID, datacenterID, creds)
+	if err := setSecureConnectBundleData(ctx, d, databaseID, datacenterID, customDomain, creds); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -139,8 +148,9 @@ func getSecureConnectBundles(ctx context.Context, client astra.ClientWithRespons
 	return *secureBundlesResp.JSON200, nil
 }
 
-func setSecureConnectBundleData(ctx context.Context, d *schema.ResourceData, databaseID, datacenterID string, bundleData []astra.CredsURL) error {
+func setSecureConnectBundleData(ctx context.Context, d *schema.ResourceData, databaseID, datacenterID, customDomain string, bundleData []astra.CredsURL) error {
 	bundles := make([]map[string]interface{}, 0, len(bundleData))
+	customDomainFound := false
 	downloadURLs := make([]string, len(bundleData))
 	for _, bundle := range bundleData {
 		// DevOps APi has a misspelling that they might fix
@@ -167,13 +177,17 @@ func setSecureConnectBundleData(ctx context.Context, d *schema.ResourceData, dat
 		if bundle.CustomDomainBundles != nil {
 			customDomainBundleArray := *bundle.CustomDomainBundles
 			customDomains := make([]map[string]interface{}, 0, len(customDomainBundleArray))
-			for _, customDomain := range customDomainBundleArray {
+			for _, customDomainBundle := range customDomainBundleArray {
+				if customDomain != "" && !strings.EqualFold(customDomainBundle.Domain, customDomain) {
+					continue
+				}
+				customDomainFound = true
 				customDomainMap := map[string]interface{}{
-					"domain":         customDomain.Domain,
-					"url":            customDomain.DownloadURL,
-					"api_fqdn":       customDomain.ApiFQDN,
-					"cql_fqdn":       customDomain.CqlFQDN,
-					"dashboard_fqdn": customDomain.DashboardFQDN,
+					"domain":         customDomainBundle.Domain,
+					"url":            customDomainBundle.DownloadURL,
+					"api_fqdn":       customDomainBundle.ApiFQDN,
+					"cql_fqdn":       customDomainBundle.CqlFQDN,
+					"dashboard_fqdn": customDomainBundle.DashboardFQDN,
 				}
 				customDomains = append(customDomains, customDomainMap)
 			}
@@ -181,6 +195,9 @@ func setSecureConnectBundleData(ctx context.Context, d *schema.ResourceData, dat
 		}
 		bundles = append(bundles, bundleMap)
 	}
+	if customDomain != "" && !customDomainFound {
+		return fmt.Errorf("no secure connect bundle found for custom domain %s of database %s, check that the domain has been validated", customDomain, databaseID)
+	}
 	// set the ID using the Database ID and the download URLs
 	d.SetId(fmt.Sprintf("%s/secure-connect-bundle/%s", databaseID, keyFromStrings(downloadURLs)))
 	d.Set("secure_bundles", bundles)
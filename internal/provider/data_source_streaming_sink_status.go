@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &streamingSinkStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &streamingSinkStatusDataSource{}
+)
+
+func NewStreamingSinkStatusDataSource() datasource.DataSource {
+	return &streamingSinkStatusDataSource{}
+}
+
+type streamingSinkStatusDataSource struct {
+	clients *astraClients2
+}
+
+type streamingSinkStatusDataSourceModel struct {
+	Cluster    types.String `tfsdk:"cluster"`
+	TenantName types.String `tfsdk:"tenant_name"`
+	Namespace  types.String `tfsdk:"namespace"`
+	SinkName   types.String `tfsdk:"sink_name"`
+	Running    types.Bool   `tfsdk:"running"`
+	pulsarConnectorStatusModel
+}
+
+func (d *streamingSinkStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_streaming_sink_status"
+}
+
+func (d *streamingSinkStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Gets the runtime status of a streaming sink.",
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				Description: "Name of the pulsar cluster of the sink.",
+				Required:    true,
+			},
+			"tenant_name": schema.StringAttribute{
+				Description: "Streaming tenant name.",
+				Required:    true,
+			},
+			"namespace": schema.StringAttribute{
+				Description: "Pulsar Namespace",
+				Required:    true,
+			},
+			"sink_name": schema.StringAttribute{
+				Description: "Name of the sink.",
+				Required:    true,
+			},
+			"running": schema.BoolAttribute{
+				Description: "Whether all the instances of the sink are running.",
+				Computed:    true,
+			},
+			"num_instances": schema.Int32Attribute{
+				Description: "Number of instances of the sink.",
+				Computed:    true,
+			},
+			"num_running": schema.Int32Attribute{
+				Description: "Number of running instances of the sink.",
+				Computed:    true,
+			},
+			"num_restarts": schema.Int64Attribute{
+				Description: "Total number of restarts of the sink instances.",
+				Computed:    true,
+			},
+			"latest_error": schema.StringAttribute{
+				Description: "Error of the first failed instance or, if no instance failed, the most recent exception reported by an instance.",
+				Computed:    true,
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "Status of each instance, as reported by the functions worker.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_id": schema.Int32Attribute{
+							Description: "ID of the instance.",
+							Computed:    true,
+						},
+						"running": schema.BoolAttribute{
+							Description: "Whether the instance is running.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Error of the instance, if it failed.",
+							Computed:    true,
+						},
+						"num_restarts": schema.Int64Attribute{
+							Description: "Number of times the instance restarted.",
+							Computed:    true,
+						},
+						"worker_id": schema.StringAttribute{
+							Description: "ID of the functions worker running the instance.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *streamingSinkStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.clients = req.ProviderData.(*astraClients2)
+}
+
+func (d *streamingSinkStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data streamingSinkStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := getPulsarConnectorStatus(ctx, d.clients.astraStreamingClient, data.Cluster.ValueString(), "sinks",
+		data.TenantName.ValueString(), data.Namespace.ValueString(), data.SinkName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to get sink status",
+			err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(data.setStatus(ctx, status)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Running = types.BoolValue(status.running())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccStreamingSinkStatusDataSource(t *testing.T) {
+	// Disable this test by default until test works with non-prod clusters
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_SINK_TEST_ENABLED")
+
+	tenantName := fmt.Sprintf("terraform-test-%s", uuid.New().String())[0:20]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingSinkConfiguration(tenantName, 1) + `
+data "astra_streaming_sink_status" "status" {
+  cluster     = astra_streaming_sink.streaming_sink_1.pulsar_cluster
+  tenant_name = astra_streaming_sink.streaming_sink_1.tenant_name
+  namespace   = astra_streaming_sink.streaming_sink_1.namespace
+  sink_name   = astra_streaming_sink.streaming_sink_1.sink_name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.astra_streaming_sink_status.status", "num_instances", "1"),
+					resource.TestCheckResourceAttrSet("data.astra_streaming_sink_status.status", "running"),
+				),
+			},
+		},
+	})
+}
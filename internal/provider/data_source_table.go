@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceTable() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_table` provides a datasource for the schema of a particular table, including its columns, primary key, clustering order, indexes and options. See `astra_tables` if you're looking to list all the tables in a keyspace.",
+
+		ReadContext: dataSourceTableRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"region": {
+				Description: "The region of the database used to access the schema endpoints.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"keyspace": {
+				Description:      "The keyspace name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+			"table": {
+				Description: "The table name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+
+			// Computed
+			"columns": {
+				Description: "The columns of the table.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The column name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The CQL type of the column.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"static": {
+							Description: "Whether the column is shared by all rows of a partition.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"partition_keys": {
+				Description: "The columns that make up the partition key, in order.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"clustering_columns": {
+				Description: "The columns that make up the clustering key, in order.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"clustering_order": {
+				Description: "The clustering order of the clustering columns.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"column": {
+							Description: "The clustering column name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"order": {
+							Description: "The clustering order, `ASC` or `DESC`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"default_time_to_live": {
+				Description: "The default TTL of the table in seconds. A value of 0 means TTL is not enabled.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"indexes": {
+				Description: "The secondary indexes defined on the table.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The index name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"kind": {
+							Description: "The index kind, e.g. `COMPOSITES` or `CUSTOM`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"options": {
+							Description: "The index options, such as the `target` column and the custom index `class_name`.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	restClients := meta.(astraClients).restClientPool
+	token := meta.(astraClients).token
+
+	databaseID := d.Get("database_id").(string)
+	region := d.Get("region").(string)
+	keyspaceName := d.Get("keyspace").(string)
+	tableName := d.Get("table").(string)
+
+	restClient, _, err := restClients.get(ctx, databaseID, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	table, err := getTableSchema(ctx, restClient, token, keyspaceName, tableName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if table == nil {
+		return diag.Errorf("table %s.%s not found in database %s", keyspaceName, tableName, databaseID)
+	}
+
+	indexes, err := listTableIndexes(ctx, restClient, token, keyspaceName, tableName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", databaseID, keyspaceName, tableName))
+	if err := setTableDataSourceData(d, table, indexes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// restIndex is a secondary index as returned by the Stargate REST indexes endpoint.
+type restIndex struct {
+	IndexName string                      `json:"index_name"`
+	Kind      string                      `json:"kind"`
+	Options   []astrarestapi.IndexOptions `json:"options"`
+}
+
+// getTableSchema returns the schema of a table, or nil if the table does not exist.
+func getTableSchema(ctx context.Context, restClient *astrarestapi.ClientWithResponses, token, keyspaceName, tableName string) (*astrarestapi.Table, error) {
+	raw := true
+	params := astrarestapi.GetTableParams{
+		Raw:             &raw,
+		XCassandraToken: token,
+	}
+	resp, err := restClient.GetTableWithResponse(ctx, keyspaceName, tableName, &params)
+	if err != nil {
+		return nil, fmt.Errorf("error getting table %s.%s: %w", keyspaceName, tableName, err)
+	} else if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode() >= 400 || resp.JSON200 == nil {
+		return nil, fmt.Errorf("error getting table %s.%s, status code: %d, message: %s", keyspaceName, tableName, resp.StatusCode(), string(resp.Body))
+	}
+	return resp.JSON200, nil
+}
+
+// listTableIndexes returns the secondary indexes of a table.  The generated client does not model the
+// response of this endpoint correctly, so the body is decoded here.
+func listTableIndexes(ctx context.Context, restClient *astrarestapi.ClientWithResponses, token, keyspaceName, tableName string) ([]restIndex, error) {
+	params := astrarestapi.GetIndexesParams{
+		XCassandraToken: token,
+	}
+	resp, err := restClient.GetIndexes(ctx, keyspaceName, tableName, &params)
+	if err != nil {
+		return nil, fmt.Errorf("error getting indexes of table %s.%s: %w", keyspaceName, tableName, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading indexes of table %s.%s: %w", keyspaceName, tableName, err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("error getting indexes of table %s.%s, status code: %d, message: %s", keyspaceName, tableName, resp.StatusCode, string(body))
+	}
+
+	var indexes []restIndex
+	if err := json.Unmarshal(body, &indexes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal indexes of table %s.%s: %w", keyspaceName, tableName, err)
+	}
+	return indexes, nil
+}
+
+func setTableDataSourceData(d *schema.ResourceData, table *astrarestapi.Table, indexes []restIndex) error {
+	columns := make([]map[string]interface{}, 0, len(table.ColumnDefinitions))
+	for _, c := range table.ColumnDefinitions {
+		columns = append(columns, map[string]interface{}{
+			"name":   c.Name,
+			"type":   string(c.TypeDefinition),
+			"static": c.Static != nil && *c.Static,
+		})
+	}
+	if err := d.Set("columns", columns); err != nil {
+		return err
+	}
+
+	if err := d.Set("partition_keys", table.PrimaryKey.PartitionKey); err != nil {
+		return err
+	}
+	clusteringColumns := []string{}
+	if table.PrimaryKey.ClusteringKey != nil {
+		clusteringColumns = *table.PrimaryKey.ClusteringKey
+	}
+	if err := d.Set("clustering_columns", clusteringColumns); err != nil {
+		return err
+	}
+
+	clusteringOrder := []map[string]interface{}{}
+	defaultTTL := 0
+	if table.TableOptions != nil {
+		if table.TableOptions.ClusteringExpression != nil {
+			for _, c := range *table.TableOptions.ClusteringExpression {
+				clusteringOrder = append(clusteringOrder, map[string]interface{}{
+					"column": c.Column,
+					"order":  string(c.Order),
+				})
+			}
+		}
+		if table.TableOptions.DefaultTimeToLive != nil {
+			defaultTTL = *table.TableOptions.DefaultTimeToLive
+		}
+	}
+	if err := d.Set("clustering_order", clusteringOrder); err != nil {
+		return err
+	}
+	if err := d.Set("default_time_to_live", defaultTTL); err != nil {
+		return err
+	}
+
+	indexList := make([]map[string]interface{}, 0, len(indexes))
+	for _, idx := range indexes {
+		options := map[string]interface{}{}
+		for _, o := range idx.Options {
+			if o.Key != nil {
+				options[*o.Key] = astra.StringValue(o.Value)
+			}
+		}
+		indexList = append(indexList, map[string]interface{}{
+			"name":    idx.IndexName,
+			"kind":    idx.Kind,
+			"options": options,
+		})
+	}
+	return d.Set("indexes", indexList)
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceTables() *schema.Resource {
+	return &schema.Resource{
+		Description: "`astra_tables` provides a datasource that lists the tables in a keyspace of an Astra database. See `astra_table` if you're looking to fetch the full schema of a particular table.",
+
+		ReadContext: dataSourceTablesRead,
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"database_id": {
+				Description:  "The ID of the Astra database.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"region": {
+				Description: "The region of the database used to access the schema endpoints.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"keyspace": {
+				Description:      "The keyspace name.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateKeyspace,
+			},
+
+			// Computed
+			"results": {
+				Type:        schema.TypeList,
+				Description: "The list of tables in the keyspace.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The table name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	restClients := meta.(astraClients).restClientPool
+	token := meta.(astraClients).token
+
+	databaseID := d.Get("database_id").(string)
+	region := d.Get("region").(string)
+	keyspaceName := d.Get("keyspace").(string)
+
+	restClient, _, err := restClients.get(ctx, databaseID, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tables, err := listTables(ctx, restClient, token, keyspaceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", databaseID, keyspaceName))
+	if err := d.Set("results", tablesToMap(tables)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// listTables returns the schema of every table in the given keyspace using the Stargate REST schema endpoints.
+func listTables(ctx context.Context, restClient *astrarestapi.ClientWithResponses, token, keyspaceName string) ([]astrarestapi.Table, error) {
+	params := astrarestapi.GetTablesParams{
+		XCassandraToken: token,
+	}
+	resp, err := restClient.GetTablesWithResponse(ctx, keyspaceName, &params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tables in keyspace %s: %w", keyspaceName, err)
+	} else if resp.StatusCode() >= 400 || resp.JSON200 == nil {
+		return nil, fmt.Errorf("error listing tables in keyspace %s, status code: %d, message: %s", keyspaceName, resp.StatusCode(), string(resp.Body))
+	}
+
+	if resp.JSON200.Data == nil {
+		return nil, nil
+	}
+	return *resp.JSON200.Data, nil
+}
+
+func tablesToMap(tables []astrarestapi.Table) []map[string]interface{} {
+	results := make([]map[string]interface{}, 0, len(tables))
+	for _, t := range tables {
+		results = append(results, map[string]interface{}{
+			"name": t.Name,
+		})
+	}
+	return results
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestTablesDataSource(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+	region := envVarOrDefault("ASTRA_TEST_DATABASE_REGION", "us-east1")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTablesDataSource(databaseID, region),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.astra_tables.dev", "results.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.astra_tables.dev", "results.*", map[string]string{"name": "mytable"}),
+					resource.TestCheckResourceAttr("data.astra_table.dev", "partition_keys.#", "2"),
+					resource.TestCheckResourceAttr("data.astra_table.dev", "clustering_columns.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+// https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
+func testAccTablesDataSource(databaseID, region string) string {
+	return fmt.Sprintf(`
+%s
+
+data "astra_tables" "dev" {
+  database_id = "%s"
+  region      = "%s"
+  keyspace    = astra_table.table-1.keyspace
+}
+
+data "astra_table" "dev" {
+  database_id = "%s"
+  region      = "%s"
+  keyspace    = astra_table.table-1.keyspace
+  table       = astra_table.table-1.table
+}
+`, testAccTableConfiguration(databaseID), databaseID, region, databaseID, region)
+}
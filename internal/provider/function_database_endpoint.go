@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &databaseEndpointFunction{}
+)
+
+// astraCQLPort is the port of the Astra CQL proxy.
+const astraCQLPort = 29042
+
+// databaseEndpointFunction builds an endpoint of a database in a given region.
+type databaseEndpointFunction struct {
+	name        string
+	description string
+	endpoint    func(databaseID, region, appsDomain string) string
+}
+
+func NewDataAPIEndpointFunction() function.Function {
+	return &databaseEndpointFunction{
+		name:        "data_api_endpoint",
+		description: "Returns the Data API endpoint of a database in the given region, for example `https://<database_id>-<region>.apps.astra.datastax.com`.",
+		endpoint:    dataAPIEndpoint,
+	}
+}
+
+func NewCQLEndpointFunction() function.Function {
+	return &databaseEndpointFunction{
+		name:        "cql_endpoint",
+		description: "Returns the CQL endpoint (host:port) of a database in the given region, for example `<database_id>-<region>.db.astra.datastax.com:29042`.",
+		endpoint:    cqlEndpoint,
+	}
+}
+
+func NewRESTEndpointFunction() function.Function {
+	return &databaseEndpointFunction{
+		name:        "rest_endpoint",
+		description: "Returns the REST API endpoint of a database in the given region, for example `https://<database_id>-<region>.apps.astra.datastax.com/api/rest`.",
+		endpoint:    restEndpoint,
+	}
+}
+
+func (f *databaseEndpointFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *databaseEndpointFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Description: f.description + " The `astra_apps_domain` of the provider configuration, or the `ASTRA_APPS_DOMAIN` environment variable, is used for non-default Astra environments.",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:        "database",
+				Description: "The database object to build the endpoint for. This should be the result of an \"astra_database\" resource or data source.",
+				AttributeTypes: map[string]attr.Type{
+					"id":             types.StringType,
+					"cloud_provider": types.StringType,
+					"datacenters": types.MapType{
+						ElemType: types.StringType,
+					},
+				},
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Description: "The region to build the endpoint for. If not provided, the function will use the database region if there is only one configured.",
+			Name:        "region",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+type partialDbWithID struct {
+	ID            string            `tfsdk:"id"`
+	CloudProvider string            `tfsdk:"cloud_provider"`
+	Datacenters   map[string]string `tfsdk:"datacenters"`
+}
+
+func (f *databaseEndpointFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var db partialDbWithID
+	var region []types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &db, &region))
+	if resp.Error != nil {
+		return
+	}
+
+	dbRegion, _, funcErr := resolveDatabaseDatacenter(db.CloudProvider, db.Datacenters, region)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, f.endpoint(db.ID, dbRegion, functionAppsDomain())))
+}
+
+// functionAppsDomain returns the Astra apps domain. Functions may be called before the provider is configured,
+// in which case the environment is used.
+func functionAppsDomain() string {
+	if astraAppsDomain != DefaultAstraAppsDomain {
+		return astraAppsDomain
+	}
+	return firstNonEmptyString(os.Getenv("ASTRA_APPS_DOMAIN"), DefaultAstraAppsDomain)
+}
+
+func dataAPIEndpoint(databaseID, region, appsDomain string) string {
+	return fmt.Sprintf("https://%s-%s.%s", databaseID, region, appsDomain)
+}
+
+func restEndpoint(databaseID, region, appsDomain string) string {
+	return fmt.Sprintf("https://%s-%s.%s/api/rest", databaseID, region, appsDomain)
+}
+
+// cqlEndpoint returns the CQL host and port. The CQL proxy is served from the "db" domain of the Astra environment.
+func cqlEndpoint(databaseID, region, appsDomain string) string {
+	dbDomain := "db." + strings.TrimPrefix(appsDomain, "apps.")
+	return fmt.Sprintf("%s-%s.%s:%d", databaseID, region, dbDomain, astraCQLPort)
+}
@@ -0,0 +1,15 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseEndpoints(t *testing.T) {
+	dbID := "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+	assert.Equal(t, "https://"+dbID+"-us-east1.apps.astra.datastax.com", dataAPIEndpoint(dbID, "us-east1", DefaultAstraAppsDomain))
+	assert.Equal(t, "https://"+dbID+"-us-east1.apps.astra.datastax.com/api/rest", restEndpoint(dbID, "us-east1", DefaultAstraAppsDomain))
+	assert.Equal(t, dbID+"-us-east1.db.astra.datastax.com:29042", cqlEndpoint(dbID, "us-east1", DefaultAstraAppsDomain))
+	assert.Equal(t, dbID+"-us-east1.db.astra-dev.datastax.com:29042", cqlEndpoint(dbID, "us-east1", "apps.astra-dev.datastax.com"))
+}
@@ -56,33 +56,38 @@ func (f *resolveDatacenterFunction) Run(ctx context.Context, req function.RunReq
 	var region []types.String
 
 	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &db, &region))
-
-	switch len(region) {
-	case 0:
-		resp.Error = resolveSingleDatacenter(ctx, db, resp)
-	case 1:
-		resp.Error = resolveDatacenterForRegion(ctx, db, region, resp)
-	default:
-		resp.Error = function.NewArgumentFuncError(1, "Only one or zero regions should be provided")
+	if resp.Error != nil {
+		return
 	}
-}
 
-func resolveSingleDatacenter(ctx context.Context, db partialDb, resp *function.RunResponse) *function.FuncError {
-	if len(db.Datacenters) == 1 {
-		for _, dc := range db.Datacenters {
-			return function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, dc))
-		}
+	_, datacenterID, funcErr := resolveDatabaseDatacenter(db.CloudProvider, db.Datacenters, region)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
 	}
-
-	return function.NewArgumentFuncError(1, "Region is required when multiple datacenters are configured")
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, datacenterID))
 }
 
-func resolveDatacenterForRegion(ctx context.Context, db partialDb, region []types.String, resp *function.RunResponse) *function.FuncError {
-	key := strings.ToUpper(db.CloudProvider) + "." + strings.ToLower(region[0].ValueString())
-
-	if dc, ok := db.Datacenters[key]; ok {
-		return function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, dc))
-	} else {
-		return function.NewArgumentFuncError(1, "No datacenter found for region: "+region[0].ValueString())
+// resolveDatabaseDatacenter returns the region and ID of the datacenter of the database matching the given region, or of
+// the only datacenter of the database if no region is given. The datacenters are keyed by "<CLOUD_PROVIDER>.<region>".
+func resolveDatabaseDatacenter(cloudProvider string, datacenters map[string]string, region []types.String) (string, string, *function.FuncError) {
+	prefix := strings.ToUpper(cloudProvider) + "."
+	switch len(region) {
+	case 0:
+		if len(datacenters) == 1 {
+			for key, dc := range datacenters {
+				return strings.TrimPrefix(key, prefix), dc, nil
+			}
+		}
+		return "", "", function.NewArgumentFuncError(1, "Region is required when multiple datacenters are configured")
+	case 1:
+		for key, dc := range datacenters {
+			if strings.EqualFold(key, prefix+region[0].ValueString()) {
+				return strings.TrimPrefix(key, prefix), dc, nil
+			}
+		}
+		return "", "", function.NewArgumentFuncError(1, "No datacenter found for region: "+region[0].ValueString())
+	default:
+		return "", "", function.NewArgumentFuncError(1, "Only one or zero regions should be provided")
 	}
 }
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveDatabaseDatacenter(t *testing.T) {
+	datacenters := map[string]string{
+		"GCP.us-east1":    "48bfc13b-c1a5-48db-b70f-b6ef9709872b-1",
+		"GCP.us-central1": "48bfc13b-c1a5-48db-b70f-b6ef9709872b-2",
+	}
+
+	region, datacenterID, err := resolveDatabaseDatacenter("gcp", datacenters, []types.String{types.StringValue("us-central1")})
+	assert.Nil(t, err)
+	assert.Equal(t, "us-central1", region)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b-2", datacenterID)
+
+	region, _, err = resolveDatabaseDatacenter("GCP", datacenters, []types.String{types.StringValue("US-EAST1")})
+	assert.Nil(t, err)
+	assert.Equal(t, "us-east1", region)
+
+	_, _, err = resolveDatabaseDatacenter("gcp", datacenters, []types.String{types.StringValue("europe-west1")})
+	assert.NotNil(t, err)
+
+	_, _, err = resolveDatabaseDatacenter("gcp", datacenters, nil)
+	assert.NotNil(t, err)
+
+	_, _, err = resolveDatabaseDatacenter("gcp", datacenters, []types.String{types.StringValue("us-east1"), types.StringValue("us-central1")})
+	assert.NotNil(t, err)
+
+	delete(datacenters, "GCP.us-central1")
+	region, datacenterID, err = resolveDatabaseDatacenter("gcp", datacenters, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "us-east1", region)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b-1", datacenterID)
+}
@@ -61,8 +61,11 @@ func NewSDKProvider(version string) func() *schema.Provider {
 			DataSourcesMap: map[string]*schema.Resource{
 				"astra_database":                  dataSourceDatabase(),
 				"astra_databases":                 dataSourceDatabases(),
+				"astra_database_schema":           dataSourceDatabaseSchema(),
 				"astra_keyspace":                  dataSourceKeyspace(),
 				"astra_keyspaces":                 dataSourceKeyspaces(),
+				"astra_table":                     dataSourceTable(),
+				"astra_tables":                    dataSourceTables(),
 				"astra_secure_connect_bundle_url": dataSourceSecureConnectBundleURL(),
 				"astra_available_regions":         dataSourceAvailableRegions(),
 				"astra_private_links":             dataSourcePrivateLinks(),
@@ -75,17 +78,16 @@ func NewSDKProvider(version string) func() *schema.Provider {
 				"astra_customer_keys":             dataSourceCustomerKeys(),
 				"astra_customer_key":              dataSourceCustomerKey(),
 				"astra_cloud_accounts":            dataSourceCloudAccounts(),
+				"astra_enterprise_orgs":           dataSourceEnterpriseOrgs(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
 				"astra_database":              resourceDatabase(),
-				"astra_keyspace":              resourceKeyspace(),
 				"astra_private_link":          resourcePrivateLink(),
 				"astra_private_link_endpoint": resourcePrivateLinkEndpoint(),
 				"astra_access_list":           resourceAccessList(),
 				"astra_role":                  resourceRole(),
 				"astra_token":                 resourceToken(),
 				"astra_cdc":                   resourceCDC(),
-				"astra_table":                 resourceTable(),
 				"astra_customer_key":          resourceCustomerKey(),
 				"astra_enterprise_org":        resourceEnterpriseOrg(),
 			},
@@ -200,14 +202,12 @@ func configure(providerVersion string, p *schema.Provider) func(context.Context,
 			return nil, diag.FromErr(err)
 		}
 
-		var clientCache = make(map[string]*astrarestapi.ClientWithResponses)
-
 		clients := astraClients{
 			astraClient:            astraClient,
 			astraStreamingClient:   streamingClient,
 			astraStreamingClientv3: streamingV3Client,
 			token:                  token,
-			stargateClientCache:    clientCache,
+			restClientPool:         newRestClientPool(astraClient, providerVersion, userAgent),
 			providerVersion:        providerVersion,
 			userAgent:              userAgent,
 		}
@@ -251,7 +251,7 @@ type astraClients struct {
 	astraStreamingClient   interface{}
 	token                  string
 	astraStreamingClientv3 *astrastreaming.ClientWithResponses
-	stargateClientCache    map[string]*astrarestapi.ClientWithResponses
+	restClientPool         *restClientPool
 	providerVersion        string
 	userAgent              string
 	streamingClusterSuffix string
@@ -10,7 +10,6 @@ import (
 	"strings"
 
 	"github.com/datastax/astra-client-go/v2/astra"
-	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
 	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
 	"github.com/datastax/pulsar-admin-client-go/src/pulsaradmin"
 	"github.com/hashicorp/go-retryablehttp"
@@ -63,7 +62,7 @@ type astraClients2 struct {
 	astraClient            *astra.ClientWithResponses
 	astraStreamingClient   *astrastreaming.ClientWithResponses
 	pulsarAdminClient      *pulsaradmin.ClientWithResponses
-	stargateClientCache    map[string]astrarestapi.Client
+	restClientPool         *restClientPool
 	providerVersion        string
 	userAgent              string
 	streamingClusterSuffix string
@@ -107,6 +106,7 @@ func (p *astraProvider) DataSources(_ context.Context) []func() datasource.DataS
 		NewPCUGroupsDataSource,
 		NewPCUGroupDataSource,
 		NewPCUGroupAssociationsDataSource,
+		NewStreamingSinkStatusDataSource,
 	}
 }
 
@@ -114,9 +114,19 @@ func (p *astraProvider) DataSources(_ context.Context) []func() datasource.DataS
 func (p *astraProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAstraCDCv3Resource,
+		NewKeyspaceResource,
+		NewTableResource,
+		NewCollectionResource,
+		NewDataAPITableResource,
+		NewDatabaseTelemetryResource,
+		NewCustomDomainResource,
+		NewAccessListEntryResource,
 		NewStreamingNamespaceResource,
 		NewStreamingPulsarTokenResource,
 		NewStreamingSinkResource,
+		NewStreamingSourceResource,
+		NewStreamingFunctionResource,
+		NewStreamingSubscriptionResource,
 		NewStreamingTenantResource,
 		NewStreamingTopicResource,
 		NewPcuGroupAssociationResource,
@@ -127,6 +137,9 @@ func (p *astraProvider) Resources(_ context.Context) []func() resource.Resource
 func (p *astraProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
 		NewResolveDatacenterFunction,
+		NewDataAPIEndpointFunction,
+		NewCQLEndpointFunction,
+		NewRESTEndpointFunction,
 	}
 }
 
@@ -240,14 +253,12 @@ func (p *astraProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	var clientCache = make(map[string]astrarestapi.Client)
-
 	clients := &astraClients2{
 		astraClient:          astraClient,
 		astraStreamingClient: streamingClient,
 		pulsarAdminClient:    pulsarAdminClient,
 		token:                astraToken,
-		stargateClientCache:  clientCache,
+		restClientPool:       newRestClientPool(astraClient, p.Version, userAgent),
 		providerVersion:      p.Version,
 		userAgent:            userAgent,
 	}
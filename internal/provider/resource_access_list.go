@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 
@@ -78,6 +79,9 @@ func resourceAccessListCreate(ctx context.Context, d *schema.ResourceData, meta
 	restricted := d.Get("enabled").(bool)
 	addressList := getAddressList(addresses)
 
+	unlock := lockAccessList(databaseID)
+	defer unlock()
+
 	addResp, err := client.AddAddressesToAccessListForDatabase(ctx,
 		astra.DatabaseIdParam(databaseID),
 		addressList,
@@ -107,7 +111,7 @@ func resourceAccessListCreate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error updating access list configuration: %d\n%s", updResp.StatusCode, respBody)
 	}
 
-	return nil
+	return accessListMixedStylesDiags(databaseID)
 }
 
 func resourceAccessListDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -120,6 +124,9 @@ func resourceAccessListDelete(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	unlock := lockAccessList(databaseID)
+	defer unlock()
+
 	accessList, err := listAccessList(ctx, client, databaseID)
 	if err != nil {
 		return diag.FromErr(err)
@@ -170,10 +177,11 @@ func resourceAccessListRead(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	if accessList != nil && string(*accessList.DatabaseId) == databaseID {
+		unmanaged := unmanagedAccessListAddresses(d.Get("addresses").([]interface{}), accessList)
 		if err := setAccessListData(d, accessList); err != nil {
 			return diag.FromErr(err)
 		}
-		return nil
+		return append(accessListMixedStylesDiags(databaseID), accessListUnmanagedAddressesDiags(databaseID, unmanaged)...)
 	}
 
 	// Not found. Remove from state.
@@ -182,6 +190,62 @@ func resourceAccessListRead(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+// accessListMixedStylesDiags returns a warning if the access list of the database is also managed by astra_access_list_entry resources.
+func accessListMixedStylesDiags(databaseID string) diag.Diagnostics {
+	if !trackAccessListResource(databaseID, accessListResourceStyleFull) {
+		return nil
+	}
+	summary, detail := accessListMixedStylesWarning(databaseID)
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  summary,
+			Detail:   detail,
+		},
+	}
+}
+
+// unmanagedAccessListAddresses returns the addresses of the access list which are not in the addresses of the state, for
+// example because astra_access_list_entry resources of another configuration added them. Nothing is returned when the
+// state has no addresses, as is the case while importing the access list.
+func unmanagedAccessListAddresses(managed []interface{}, accessList *astra.AccessListResponse) []string {
+	if len(managed) == 0 || accessList.Addresses == nil {
+		return nil
+	}
+	var unmanaged []string
+	for _, addr := range *accessList.Addresses {
+		address := astra.StringValue(addr.Address)
+		found := false
+		for _, m := range managed {
+			if sameAccessListAddress(m.(map[string]interface{})["address"].(string), address) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unmanaged = append(unmanaged, address)
+		}
+	}
+	return unmanaged
+}
+
+// accessListUnmanagedAddressesDiags returns a warning if the access list of the database contains addresses which are not
+// managed by the astra_access_list resource.
+func accessListUnmanagedAddressesDiags(databaseID string, unmanaged []string) diag.Diagnostics {
+	if len(unmanaged) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Access list contains addresses not managed by astra_access_list",
+			Detail: fmt.Sprintf("The access list of database %s contains addresses which are not managed by this astra_access_list resource: %s. "+
+				"They may be managed by astra_access_list_entry resources in another configuration, and would be removed when the access list is replaced. "+
+				"Use only one resource style per database.", databaseID, strings.Join(unmanaged, ", ")),
+		},
+	}
+}
+
 func setAccessListData(d *schema.ResourceData, accessList *astra.AccessListResponse) error {
 	if err := d.Set("database_id", *accessList.DatabaseId); err != nil {
 		return err
@@ -0,0 +1,355 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &accessListEntryResource{}
+	_ resource.ResourceWithConfigure   = &accessListEntryResource{}
+	_ resource.ResourceWithImportState = &accessListEntryResource{}
+)
+
+var (
+	// accessListMutexes holds a mutex per database, to serialize the read-modify-write of its access list.
+	accessListMutexes sync.Map
+	// accessListResources records the databases whose access list is managed by astra_access_list and
+	// astra_access_list_entry resources, to detect both resource styles managing the same database. It only covers the
+	// resources of a single provider process, astra_access_list also warns about addresses of the access list which are not
+	// in its state, to detect astra_access_list_entry resources of other configurations.
+	accessListResources sync.Map
+)
+
+const (
+	accessListResourceStyleFull  = "astra_access_list"
+	accessListResourceStyleEntry = "astra_access_list_entry"
+)
+
+// lockAccessList locks the access list of the database and returns the function to unlock it.
+func lockAccessList(databaseID string) func() {
+	mu, _ := accessListMutexes.LoadOrStore(strings.ToLower(databaseID), &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// trackAccessListResource records that the access list of the database is managed by a resource of the given style, and
+// returns true if it is also managed by the other style.
+func trackAccessListResource(databaseID, style string) bool {
+	other := accessListResourceStyleFull
+	if style == accessListResourceStyleFull {
+		other = accessListResourceStyleEntry
+	}
+	databaseID = strings.ToLower(databaseID)
+	accessListResources.Store(style+"/"+databaseID, true)
+	_, found := accessListResources.Load(other + "/" + databaseID)
+	return found
+}
+
+func accessListMixedStylesWarning(databaseID string) (string, string) {
+	return "Access list managed by both astra_access_list and astra_access_list_entry",
+		fmt.Sprintf("The access list of database %s is managed by both astra_access_list and astra_access_list_entry resources. "+
+			"astra_access_list owns the full list of addresses, so the resources will overwrite each other. Use only one resource style per database.", databaseID)
+}
+
+func NewAccessListEntryResource() resource.Resource {
+	return &accessListEntryResource{}
+}
+
+type accessListEntryResource struct {
+	clients *astraClients2
+}
+
+type accessListEntryResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	DatabaseID  types.String `tfsdk:"database_id"`
+	Address     types.String `tfsdk:"address"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *accessListEntryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_list_entry"
+}
+
+func (r *accessListEntryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`astra_access_list_entry` manages a single IP address or CIDR group in the access list of a database, so several " +
+			"configurations can contribute addresses to the same database. It must not be combined with `astra_access_list` for the same database, " +
+			"as `astra_access_list` owns the full list of addresses. A warning is reported when both resource styles manage the same database in the same configuration. Across configurations, `astra_access_list` instead warns about the addresses of the access list which are not in its state.\n\n" +
+			"Access list entries can be imported with an ID of the form `database_id/address`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the access list entry, in the format `database_id/address`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database_id": schema.StringAttribute{
+				Description: "The ID of the Astra database.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegex, "must be a valid UUID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address": schema.StringAttribute{
+				Description: "IP Address/CIDR group that should have access, for example `192.0.2.0/24`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description for the IP Address/CIDR group.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Enable/disable this IP Address/CIDR group's access. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *accessListEntryResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+func (r *accessListEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan accessListEntryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID := plan.DatabaseID.ValueString()
+	address := plan.Address.ValueString()
+	unlock := lockAccessList(databaseID)
+	defer unlock()
+
+	accessList, err := listAccessList(ctx, r.clients.astraClient, databaseID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading access list", err.Error())
+		return
+	} else if accessList == nil {
+		resp.Diagnostics.AddError("Error creating access list entry", fmt.Sprintf("database %s is terminated", databaseID))
+		return
+	}
+	if findAccessListAddress(accessList, address) != nil {
+		resp.Diagnostics.AddError("Error creating access list entry",
+			fmt.Sprintf("address %s is already in the access list of database %s, import it with the ID %s", address, databaseID, accessListEntryID(databaseID, address)))
+		return
+	}
+
+	addResp, err := r.clients.astraClient.AddAddressesToAccessListForDatabase(ctx, astra.DatabaseIdParam(databaseID), []astra.AddressRequest{plan.addressRequest()})
+	resp.Diagnostics.Append(HTTPResponseDiagErr(addResp, err, "Error creating access list entry")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(accessListEntryID(databaseID, address))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	r.warnMixedStyles(databaseID, &resp.Diagnostics)
+}
+
+func (r *accessListEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state accessListEntryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID := state.DatabaseID.ValueString()
+	accessList, err := listAccessList(ctx, r.clients.astraClient, databaseID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading access list", err.Error())
+		return
+	}
+	var addr *astra.AddressResponse
+	if accessList != nil {
+		addr = findAccessListAddress(accessList, state.Address.ValueString())
+	}
+	if addr == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Description = types.StringValue(astra.StringValue(addr.Description))
+	state.Enabled = types.BoolValue(addr.Enabled != nil && *addr.Enabled)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	r.warnMixedStyles(databaseID, &resp.Diagnostics)
+}
+
+func (r *accessListEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan accessListEntryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID := plan.DatabaseID.ValueString()
+	unlock := lockAccessList(databaseID)
+	defer unlock()
+
+	accessList, err := listAccessList(ctx, r.clients.astraClient, databaseID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading access list", err.Error())
+		return
+	} else if accessList == nil || findAccessListAddress(accessList, plan.Address.ValueString()) == nil {
+		resp.Diagnostics.AddError("Error updating access list entry",
+			fmt.Sprintf("address %s is not in the access list of database %s", plan.Address.ValueString(), databaseID))
+		return
+	}
+
+	// the access list can only be updated as a whole
+	addresses := []astra.AddressRequest{}
+	if accessList.Addresses != nil {
+		for _, addr := range *accessList.Addresses {
+			if sameAccessListAddress(astra.StringValue(addr.Address), plan.Address.ValueString()) {
+				addresses = append(addresses, plan.addressRequest())
+				continue
+			}
+			addresses = append(addresses, astra.AddressRequest{
+				Address:     astra.StringValue(addr.Address),
+				Description: astra.StringValue(addr.Description),
+				Enabled:     addr.Enabled != nil && *addr.Enabled,
+			})
+		}
+	}
+	updResp, err := r.clients.astraClient.UpdateAccessListForDatabase(ctx, astra.DatabaseIdParam(databaseID), astra.UpdateAccessListForDatabaseJSONRequestBody{
+		Addresses:      &addresses,
+		Configurations: accessList.Configurations,
+	})
+	resp.Diagnostics.Append(HTTPResponseDiagErr(updResp, err, "Error updating access list entry")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *accessListEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state accessListEntryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID := state.DatabaseID.ValueString()
+	unlock := lockAccessList(databaseID)
+	defer unlock()
+
+	accessList, err := listAccessList(ctx, r.clients.astraClient, databaseID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading access list", err.Error())
+		return
+	}
+	var addr *astra.AddressResponse
+	if accessList != nil {
+		addr = findAccessListAddress(accessList, state.Address.ValueString())
+	}
+	if addr == nil {
+		// already removed
+		return
+	}
+
+	params := &astra.DeleteAddressesOrAccessListForDatabaseParams{Addresses: &astra.AddressesQueryParam{*addr.Address}}
+	delResp, err := r.clients.astraClient.DeleteAddressesOrAccessListForDatabase(ctx, astra.DatabaseIdParam(databaseID), params)
+	if err == nil && delResp.StatusCode >= 300 {
+		body, _ := io.ReadAll(delResp.Body)
+		err = fmt.Errorf("unexpected status code: %v, message: '%s'", delResp.StatusCode, body)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting access list entry", err.Error())
+	}
+}
+
+func (r *accessListEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	databaseID, address, err := parseAccessListEntryID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing access list entry", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_id"), databaseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("address"), address)...)
+}
+
+func (r *accessListEntryResource) warnMixedStyles(databaseID string, diags *diag.Diagnostics) {
+	if trackAccessListResource(databaseID, accessListResourceStyleEntry) {
+		diags.AddWarning(accessListMixedStylesWarning(databaseID))
+	}
+}
+
+func (m *accessListEntryResourceModel) addressRequest() astra.AddressRequest {
+	return astra.AddressRequest{
+		Address:     m.Address.ValueString(),
+		Description: m.Description.ValueString(),
+		Enabled:     m.Enabled.ValueBool(),
+	}
+}
+
+// findAccessListAddress returns the address of the access list, or nil if it is not in the access list.
+func findAccessListAddress(accessList *astra.AccessListResponse, address string) *astra.AddressResponse {
+	if accessList.Addresses == nil {
+		return nil
+	}
+	for _, addr := range *accessList.Addresses {
+		if addr.Address != nil && sameAccessListAddress(*addr.Address, address) {
+			return &addr
+		}
+	}
+	return nil
+}
+
+// sameAccessListAddress returns true if both addresses are equal, a single IPv4 address being equal to its /32 CIDR group.
+func sameAccessListAddress(a, b string) bool {
+	normalize := func(address string) string {
+		if !strings.Contains(address, "/") && !strings.Contains(address, ":") {
+			return address + "/32"
+		}
+		return strings.ToLower(address)
+	}
+	return normalize(a) == normalize(b)
+}
+
+func accessListEntryID(databaseID, address string) string {
+	return databaseID + "/" + address
+}
+
+// parseAccessListEntryID returns the database ID and address from an ID of the form database_id/address. The address may
+// contain a slash as a CIDR group.
+func parseAccessListEntryID(id string) (string, string, error) {
+	databaseID, address, ok := strings.Cut(id, "/")
+	if !ok || databaseID == "" || address == "" {
+		return "", "", fmt.Errorf("invalid access list entry id format %q: expected database_id/address", id)
+	}
+	return databaseID, address, nil
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessListEntry(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessListEntryConfiguration(databaseID, "app team"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_access_list_entry.app", "description", "app team"),
+					resource.TestCheckResourceAttr("astra_access_list_entry.security", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccAccessListEntryConfiguration(databaseID, "app team updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_access_list_entry.app", "description", "app team updated"),
+				),
+			},
+			{
+				ResourceName:      "astra_access_list_entry.app",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAccessListEntryConfiguration(databaseID, description string) string {
+	return fmt.Sprintf(`
+resource "astra_access_list_entry" "app" {
+  database_id = "%[1]s"
+  address     = "192.0.2.0/24"
+  description = "%[2]s"
+}
+
+resource "astra_access_list_entry" "security" {
+  database_id = "%[1]s"
+  address     = "198.51.100.0/24"
+}
+`, databaseID, description)
+}
+
+func TestParseAccessListEntryID(t *testing.T) {
+	databaseID, address, err := parseAccessListEntryID(accessListEntryID("48bfc13b-c1a5-48db-b70f-b6ef9709872b", "192.0.2.0/24"))
+	assert.NoError(t, err)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b", databaseID)
+	assert.Equal(t, "192.0.2.0/24", address)
+
+	for _, id := range []string{"", "48bfc13b-c1a5-48db-b70f-b6ef9709872b", "48bfc13b-c1a5-48db-b70f-b6ef9709872b/", "/192.0.2.0/24"} {
+		_, _, err := parseAccessListEntryID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestSameAccessListAddress(t *testing.T) {
+	assert.True(t, sameAccessListAddress("192.0.2.1", "192.0.2.1/32"))
+	assert.True(t, sameAccessListAddress("192.0.2.0/24", "192.0.2.0/24"))
+	assert.False(t, sameAccessListAddress("192.0.2.0/24", "192.0.2.0/25"))
+}
+
+func TestTrackAccessListResource(t *testing.T) {
+	databaseID := "00000000-0000-0000-0000-000000000040"
+	assert.False(t, trackAccessListResource(databaseID, accessListResourceStyleEntry))
+	assert.False(t, trackAccessListResource(databaseID, accessListResourceStyleEntry))
+	assert.True(t, trackAccessListResource(databaseID, accessListResourceStyleFull))
+	assert.True(t, trackAccessListResource(databaseID, accessListResourceStyleEntry))
+}
+
+func TestUnmanagedAccessListAddresses(t *testing.T) {
+	accessList := &astra.AccessListResponse{Addresses: &[]astra.AddressResponse{
+		{Address: astra.StringPtr("192.0.2.1/32")},
+		{Address: astra.StringPtr("198.51.100.0/24")},
+	}}
+	managed := []interface{}{map[string]interface{}{"address": "192.0.2.1"}}
+	assert.Equal(t, []string{"198.51.100.0/24"}, unmanagedAccessListAddresses(managed, accessList))
+	assert.Len(t, accessListUnmanagedAddressesDiags("db", []string{"198.51.100.0/24"}), 1)
+	assert.Empty(t, accessListUnmanagedAddressesDiags("db", nil))
+
+	// an imported access list has no addresses in its state yet
+	assert.Empty(t, unmanagedAccessListAddresses(nil, accessList))
+}
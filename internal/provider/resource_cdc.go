@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"regexp"
 	"strings"
 	"sync"
@@ -71,6 +72,7 @@ func resourceCDC() *schema.Resource {
 					"determine the pulsar cluster name based on the database cloud provider and region",
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"tenant_name": {
@@ -239,6 +241,11 @@ func resourceCDCRead(ctx context.Context, resourceData *schema.ResourceData, met
 	}
 
 	if cdcStatus := getTableCDCStatus(databaseId, keyspace, table, cdcResult); cdcStatus != nil {
+		if partitions, err := getCDCTopicPartitions(ctx, streamingClient, pulsarCluster, cdcStatus.DataTopic); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("failed to get the partitions of the CDC data topic: %v", err))
+		} else if err := resourceData.Set("topic_partitions", partitions); err != nil {
+			return diag.FromErr(err)
+		}
 		return setCDCData(resourceData, *cdcStatus)
 	}
 
@@ -457,11 +464,19 @@ func getPulsarToken(ctx context.Context, pulsarCluster string, token string, org
 }
 
 func setCDCData(resourceData *schema.ResourceData, cdc CDCStatus) diag.Diagnostics {
-	if err := resourceData.Set("connector_status", cdc.CodStatus); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := resourceData.Set("data_topic", cdc.DataTopic); err != nil {
-		return diag.FromErr(err)
+	for attr, value := range map[string]string{
+		"database_id":      cdc.DatabaseID,
+		"database_name":    cdc.DatabaseName,
+		"keyspace":         cdc.Keyspace,
+		"table":            cdc.DatabaseTable,
+		"tenant_name":      cdc.Tenant,
+		"pulsar_cluster":   cdc.ClusterName,
+		"connector_status": cdc.CodStatus,
+		"data_topic":       cdc.DataTopic,
+	} {
+		if err := resourceData.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	cdcId := fmt.Sprintf("%s/%s/%s/%s", cdc.DatabaseID, cdc.Keyspace, cdc.DatabaseTable, cdc.Tenant)
@@ -470,6 +485,27 @@ func setCDCData(resourceData *schema.ResourceData, cdc CDCStatus) diag.Diagnosti
 	return nil
 }
 
+// getCDCTopicPartitions returns the number of partitions of a CDC data topic, e.g. "persistent://my-tenant/astracdc/data-my-table".
+func getCDCTopicPartitions(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, pulsarCluster, dataTopic string) (int, error) {
+	topicPath := strings.TrimPrefix(dataTopic, "persistent://")
+	if topicPath == dataTopic || len(strings.Split(topicPath, "/")) != 3 {
+		return 0, fmt.Errorf("invalid data topic '%s'", dataTopic)
+	}
+	body, statusCode, err := streamingRequest(ctx, streamingClient, pulsarCluster, http.MethodGet, "admin/v2/persistent/"+topicPath+"/partitions", nil)
+	if err != nil {
+		return 0, err
+	} else if statusCode > 299 {
+		return 0, fmt.Errorf("failed to get partitions of topic '%s', status code: %d, message: %s", dataTopic, statusCode, string(body))
+	}
+	var metadata struct {
+		Partitions int `json:"partitions"`
+	}
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal partitions of topic '%s': %w", dataTopic, err)
+	}
+	return metadata.Partitions, nil
+}
+
 // parseCDCID expects an ID in the format "databaseId/keyspace/table/tenantName"
 func parseCDCID(id string) (string, string, string, string, error) {
 	idParts := strings.Split(strings.ToLower(id), "/")
@@ -5,13 +5,15 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 )
 
 // https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
 func TestAccAstraCDC(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAstraCDCConfig(),
@@ -38,8 +40,8 @@ func TestAstraCDCFull(t *testing.T) {
 	checkRequiredTestVars(t, "ASTRA_TEST_CDC_FULL_TEST_ENABLED")
 	streamingTenant := "terraform-cdc-test-" + randomString(6)
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAstraCDCConfigFull("GCP", "us-east1", streamingTenant),
@@ -78,6 +80,7 @@ resource "astra_table" "table_1" {
       TypeDefinition: "text"
     }
   ]
+  deletion_protection = false
 }
 
 resource "astra_streaming_tenant" "streaming_tenant_1" {
@@ -100,3 +103,28 @@ resource "astra_streaming_tenant" "streaming_tenant_1" {
 }`, cloud_provider, region, region, streamingTenant)
 
 }
+
+func TestSetCDCData(t *testing.T) {
+	// an imported CDC only has an ID
+	resourceData := schema.TestResourceDataRaw(t, resourceCDC().Schema, map[string]interface{}{})
+	resourceData.SetId("cfdf8243-4ea5-453f-8800-ed6f7eb125a4/ks1/tbl2/my-tenant")
+
+	diags := setCDCData(resourceData, CDCStatus{
+		ClusterName:   "pulsar-gcp-useast1",
+		Tenant:        "my-tenant",
+		DatabaseID:    "cfdf8243-4ea5-453f-8800-ed6f7eb125a4",
+		DatabaseName:  "my-db",
+		Keyspace:      "ks1",
+		DatabaseTable: "tbl2",
+		CodStatus:     "Active",
+		DataTopic:     "persistent://my-tenant/astracdc/data-cfdf8243-4ea5-453f-8800-ed6f7eb125a4-ks1.tbl2",
+	})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "cfdf8243-4ea5-453f-8800-ed6f7eb125a4/ks1/tbl2/my-tenant", resourceData.Id())
+	assert.Equal(t, "my-db", resourceData.Get("database_name"))
+	assert.Equal(t, "ks1", resourceData.Get("keyspace"))
+	assert.Equal(t, "tbl2", resourceData.Get("table"))
+	assert.Equal(t, "my-tenant", resourceData.Get("tenant_name"))
+	assert.Equal(t, "pulsar-gcp-useast1", resourceData.Get("pulsar_cluster"))
+	assert.Equal(t, "Active", resourceData.Get("connector_status"))
+}
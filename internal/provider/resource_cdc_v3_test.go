@@ -11,8 +11,8 @@ func TestAstraCDCv3(t *testing.T) {
 	checkRequiredTestVars(t, "ASTRA_TEST_CDC_V3_TEST_ENABLED")
 	streamingTenant := "terraform-cdcv3-test-" + randomString(4)
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAstraCDCv3Config("GCP", "us-east1", streamingTenant),
@@ -51,6 +51,7 @@ resource "astra_table" "table_1" {
       TypeDefinition: "text"
     }
   ]
+  deletion_protection = false
 }
 
 resource "astra_table" "table_2" {
@@ -72,6 +73,7 @@ resource "astra_table" "table_2" {
       TypeDefinition: "text"
     }
   ]
+  deletion_protection = false
 }
 
 resource "astra_streaming_tenant" "tenant_1" {
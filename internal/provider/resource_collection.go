@@ -0,0 +1,728 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultDataAPIKeyspace = "default_keyspace"
+
+var (
+	_ resource.Resource                   = &collectionResource{}
+	_ resource.ResourceWithConfigure      = &collectionResource{}
+	_ resource.ResourceWithImportState    = &collectionResource{}
+	_ resource.ResourceWithIdentity       = &collectionResource{}
+	_ resource.ResourceWithValidateConfig = &collectionResource{}
+	_ resource.ResourceWithModifyPlan     = &collectionResource{}
+)
+
+func NewCollectionResource() resource.Resource {
+	return &collectionResource{}
+}
+
+type collectionResource struct {
+	clients *astraClients2
+}
+
+type collectionResourceModel struct {
+	ID                 types.String             `tfsdk:"id"`
+	DatabaseID         types.String             `tfsdk:"database_id"`
+	Region             types.String             `tfsdk:"region"`
+	Keyspace           types.String             `tfsdk:"keyspace"`
+	Name               types.String             `tfsdk:"name"`
+	Vector             *collectionVectorModel   `tfsdk:"vector"`
+	Indexing           *collectionIndexingModel `tfsdk:"indexing"`
+	DefaultIDType      types.String             `tfsdk:"default_id_type"`
+	DeletionProtection types.Bool               `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value           `tfsdk:"timeouts"`
+}
+
+type collectionVectorModel struct {
+	Dimension types.Int64                   `tfsdk:"dimension"`
+	Metric    types.String                  `tfsdk:"metric"`
+	Service   *collectionVectorServiceModel `tfsdk:"service"`
+}
+
+type collectionVectorServiceModel struct {
+	Provider       types.String      `tfsdk:"provider"`
+	ModelName      types.String      `tfsdk:"model_name"`
+	Authentication map[string]string `tfsdk:"authentication"`
+	Parameters     map[string]string `tfsdk:"parameters"`
+}
+
+type collectionIndexingModel struct {
+	Allow []string `tfsdk:"allow"`
+	Deny  []string `tfsdk:"deny"`
+}
+
+type collectionResourceIdentityModel struct {
+	DatabaseID types.String `tfsdk:"database_id"`
+	Keyspace   types.String `tfsdk:"keyspace"`
+	Name       types.String `tfsdk:"name"`
+}
+
+func (r *collectionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *collectionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`astra_collection` provides a Data API collection resource. Collections store JSON documents, and optionally vectors, in a keyspace of an Astra database. They are managed through the Data API at the data endpoint of the database.\n\n" +
+			"Collections can be imported with an ID of the form `database_id/keyspace/name`, or with an `import` block using the `database_id`, `keyspace` and `name` identity attributes (Terraform v1.12.0 and later).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the collection, in the format `database_id/keyspace/name`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database_id": schema.StringAttribute{
+				Description: "Astra database to create the collection in.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegex, "must be a valid UUID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "Region of the datacenter to send Data API requests to. Defaults to any healthy datacenter of the database.",
+				Optional:    true,
+			},
+			"keyspace": schema.StringAttribute{
+				Description: fmt.Sprintf("Keyspace to create the collection in. Defaults to `%s`.", defaultDataAPIKeyspace),
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultDataAPIKeyspace),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyspaceNameRegex, "invalid keyspace name"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Collection name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(collectionNameRegex, "invalid collection name"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vector": schema.SingleNestedAttribute{
+				Description: "Vector configuration of the collection. Omit for a collection without vectors.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"dimension": schema.Int64Attribute{
+						Description: "Number of dimensions of the vectors stored in the collection. Required unless `service` is set with a model which generates vectors of a fixed dimension.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+							int64planmodifier.RequiresReplace(),
+						},
+					},
+					"metric": schema.StringAttribute{
+						Description: "Similarity metric used for vector searches, one of `cosine`, `euclidean` or `dot_product`. Defaults to `cosine`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("cosine"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("cosine", "euclidean", "dot_product"),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"service": schema.SingleNestedAttribute{
+						Description: "Embedding provider used to generate vectors server side (vectorize). The configuration is validated against the embedding providers supported by the database.",
+						Optional:    true,
+						PlanModifiers: []planmodifier.Object{
+							objectplanmodifier.RequiresReplace(),
+						},
+						Attributes: vectorServiceAttributes(),
+					},
+				},
+			},
+			"indexing": schema.SingleNestedAttribute{
+				Description: "Selective indexing of document fields. Only one of `allow` and `deny` can be set. By default all fields are indexed.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"allow": schema.SetAttribute{
+						Description: "Fields to index, all the other fields are not indexed. Use `[\"*\"]` to index all fields.",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("deny")),
+							setvalidator.SizeAtLeast(1),
+						},
+						PlanModifiers: []planmodifier.Set{
+							setplanmodifier.RequiresReplace(),
+						},
+					},
+					"deny": schema.SetAttribute{
+						Description: "Fields not to index, all the other fields are indexed. Use `[\"*\"]` to disable indexing.",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+						},
+						PlanModifiers: []planmodifier.Set{
+							setplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+			"default_id_type": schema.StringAttribute{
+				Description: "Type of the `_id` generated for documents inserted without one, one of `uuid`, `uuidv6`, `uuidv7` or `objectId`. Defaults to a Data API generated string.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("uuid", "uuidv6", "uuidv7", "objectId"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether or not to allow Terraform to destroy the collection, and the documents it contains. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes or replaces the collection will fail. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *collectionResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"database_id": identityschema.StringAttribute{
+				Description:       "The ID of the Astra database.",
+				RequiredForImport: true,
+			},
+			"keyspace": identityschema.StringAttribute{
+				Description:       "The keyspace of the collection.",
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The collection name.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *collectionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+func (r *collectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan collectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	databaseID := plan.DatabaseID.ValueString()
+	if err := waitForDatabaseActive(ctx, r.clients.astraClient, databaseID); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to wait for database '%s' to be active", databaseID), err.Error())
+		return
+	}
+
+	dataAPI, _, err := r.clients.restClientPool.getDataAPI(ctx, databaseID, plan.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating collection", err.Error())
+		return
+	}
+
+	collection := plan.collection()
+	if vector := collection.Options.Vector; vector != nil && vector.Service != nil {
+		providers, err := dataAPI.findEmbeddingProviders(ctx, r.clients.token)
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating collection", fmt.Sprintf("failed to fetch embedding providers: %s", err))
+			return
+		}
+		if vector.Dimension, err = validateVectorService(providers, vector.Service, vector.Dimension); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("vector").AtName("service"), "Invalid vector service", err.Error())
+			return
+		}
+	}
+
+	if err := dataAPI.createCollection(ctx, r.clients.token, plan.Keyspace.ValueString(), collection); err != nil {
+		resp.Diagnostics.AddError("Error creating collection", err.Error())
+		return
+	}
+
+	if plan.Vector != nil {
+		// The dimension may be determined by the embedding model
+		plan.Vector.Dimension = types.Int64PointerValue(collection.Options.Vector.Dimension)
+	}
+
+	plan.ID = types.StringValue(collectionID(databaseID, plan.Keyspace.ValueString(), plan.Name.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
+}
+
+func (r *collectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state collectionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID := state.DatabaseID.ValueString()
+	dataAPI, _, err := r.clients.restClientPool.getDataAPI(ctx, databaseID, state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading collection", err.Error())
+		return
+	}
+	collection, err := dataAPI.findCollection(ctx, r.clients.token, state.Keyspace.ValueString(), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading collection", err.Error())
+		return
+	} else if collection == nil {
+		// Collection not found. Remove from state.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.setCollection(collection)
+	state.ID = types.StringValue(collectionID(databaseID, state.Keyspace.ValueString(), state.Name.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, state.identity())...)
+}
+
+func (r *collectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var vector, service types.Object
+	var dimension types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vector"), &vector)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vector").AtName("dimension"), &dimension)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vector").AtName("service"), &service)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !vector.IsNull() && !vector.IsUnknown() && dimension.IsNull() && service.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("vector").AtName("dimension"), "Missing vector dimension",
+			"\"dimension\" must be set for collections without an embedding provider \"service\"")
+	}
+}
+
+// ModifyPlan validates the vector service against the embedding providers of the database when a collection is created,
+// so that mistakes are reported by `terraform plan`. The validation is skipped when the database is not available yet,
+// Create validates the configuration again in any case.
+func (r *collectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.clients == nil {
+		return
+	}
+
+	var planVector, stateVector types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("vector"), &planVector)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("vector"), &stateVector)...)
+	}
+	if resp.Diagnostics.HasError() || planVector.IsNull() || planVector.Equal(stateVector) {
+		return
+	}
+
+	var plan collectionResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		// Some values are not known yet, they are validated at apply time
+		return
+	}
+	if plan.DatabaseID.IsUnknown() || plan.Vector == nil || plan.Vector.Service == nil || plan.Vector.Service.Provider.IsUnknown() || plan.Vector.Service.ModelName.IsUnknown() {
+		return
+	}
+
+	dataAPI, _, err := r.clients.restClientPool.getDataAPI(ctx, plan.DatabaseID.ValueString(), plan.Region.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("skipping validation of the vector service of collection %s: %v", plan.Name.ValueString(), err))
+		return
+	}
+	providers, err := dataAPI.findEmbeddingProviders(ctx, r.clients.token)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("skipping validation of the vector service of collection %s: %v", plan.Name.ValueString(), err))
+		return
+	}
+
+	vector := plan.collection().Options.Vector
+	if vector.Dimension, err = validateVectorService(providers, vector.Service, vector.Dimension); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("vector").AtName("service"), "Invalid vector service", err.Error())
+		return
+	}
+	if plan.Vector.Dimension.IsUnknown() && vector.Dimension != nil {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("vector").AtName("dimension"), *vector.Dimension)...)
+	}
+}
+
+// Update only handles the attributes which are not part of the collection definition, all the other attributes force a
+// new collection.
+func (r *collectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan collectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
+}
+
+func (r *collectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state collectionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Error deleting collection", "\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_collection")
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	dataAPI, _, err := r.clients.restClientPool.getDataAPI(ctx, state.DatabaseID.ValueString(), state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting collection", err.Error())
+		return
+	}
+	if err := dataAPI.deleteCollection(ctx, r.clients.token, state.Keyspace.ValueString(), state.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error deleting collection", err.Error())
+	}
+}
+
+// ImportState accepts either an ID of the form database_id/keyspace/name, or the resource identity.
+func (r *collectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var databaseID, keyspaceName, collectionName string
+	if req.ID != "" {
+		var err error
+		databaseID, keyspaceName, collectionName, err = parseCollectionID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing collection", err.Error())
+			return
+		}
+	} else {
+		var identity collectionResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		databaseID = identity.DatabaseID.ValueString()
+		keyspaceName = identity.Keyspace.ValueString()
+		collectionName = identity.Name.ValueString()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), collectionID(databaseID, keyspaceName, collectionName))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_id"), databaseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("keyspace"), keyspaceName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), collectionName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+}
+
+func (m collectionResourceModel) identity() collectionResourceIdentityModel {
+	return collectionResourceIdentityModel{
+		DatabaseID: m.DatabaseID,
+		Keyspace:   m.Keyspace,
+		Name:       m.Name,
+	}
+}
+
+// collection returns the Data API definition of the collection described by the model.
+func (m collectionResourceModel) collection() dataAPICollection {
+	collection := dataAPICollection{Name: m.Name.ValueString()}
+	if m.Vector != nil {
+		collection.Options.Vector = &dataAPICollectionVector{
+			Dimension: knownInt64Pointer(m.Vector.Dimension),
+			Metric:    m.Vector.Metric.ValueString(),
+		}
+		collection.Options.Vector.Service = m.Vector.Service.service()
+	}
+	if m.Indexing != nil && (len(m.Indexing.Allow) > 0 || len(m.Indexing.Deny) > 0) {
+		collection.Options.Indexing = &dataAPICollectionIndexing{
+			Allow: m.Indexing.Allow,
+			Deny:  m.Indexing.Deny,
+		}
+	}
+	if !m.DefaultIDType.IsNull() && !m.DefaultIDType.IsUnknown() {
+		collection.Options.DefaultID = &dataAPICollectionDefaultID{Type: m.DefaultIDType.ValueString()}
+	}
+	return collection
+}
+
+// setCollection updates the model with the definition of the collection returned by the Data API, so that changes made
+// outside of Terraform are detected.
+func (m *collectionResourceModel) setCollection(collection *dataAPICollection) {
+	options := collection.Options
+	if options.Vector != nil && options.Vector.Dimension != nil {
+		metric := options.Vector.Metric
+		if metric == "" {
+			metric = "cosine"
+		}
+		var priorService *collectionVectorServiceModel
+		if m.Vector != nil {
+			priorService = m.Vector.Service
+		}
+		m.Vector = &collectionVectorModel{
+			Dimension: types.Int64PointerValue(options.Vector.Dimension),
+			Metric:    types.StringValue(metric),
+			Service:   vectorServiceModel(options.Vector.Service, priorService),
+		}
+	} else {
+		m.Vector = nil
+	}
+
+	if options.Indexing != nil && (len(options.Indexing.Allow) > 0 || len(options.Indexing.Deny) > 0) {
+		m.Indexing = &collectionIndexingModel{
+			Allow: options.Indexing.Allow,
+			Deny:  options.Indexing.Deny,
+		}
+	} else {
+		m.Indexing = nil
+	}
+
+	if options.DefaultID != nil && options.DefaultID.Type != "" {
+		m.DefaultIDType = types.StringValue(options.DefaultID.Type)
+	} else {
+		m.DefaultIDType = types.StringNull()
+	}
+}
+
+// vectorServiceAttributes returns the schema of a vectorize service definition, shared by collections and vector columns
+// of tables.
+func vectorServiceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"provider": schema.StringAttribute{
+			Description: "Name of the embedding provider, for example `openai` or `nvidia`.",
+			Required:    true,
+		},
+		"model_name": schema.StringAttribute{
+			Description: "Name of the embedding model. Required for providers which offer several models.",
+			Optional:    true,
+		},
+		"authentication": schema.MapAttribute{
+			Description: "Authentication of the embedding provider. Maps the credential expected by the provider, usually `providerKey`, to the name of a shared secret key of the Astra organization.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"parameters": schema.MapAttribute{
+			Description: "Parameters of the embedding provider or model, as strings. Numbers and booleans are converted according to the types of the parameters in the embedding provider catalog.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// service returns the Data API definition of the vector service. Parameters are sent as strings until validateVectorService
+// converts them to the types of the catalog.
+func (m *collectionVectorServiceModel) service() *dataAPIVectorService {
+	if m == nil {
+		return nil
+	}
+	parameters := map[string]interface{}{}
+	for k, v := range m.Parameters {
+		parameters[k] = v
+	}
+	return &dataAPIVectorService{
+		Provider:       m.Provider.ValueString(),
+		ModelName:      m.ModelName.ValueString(),
+		Authentication: m.Authentication,
+		Parameters:     parameters,
+	}
+}
+
+// vectorServiceModel converts the vector service returned by the Data API. Parameters which were not configured are only
+// kept when there is no prior configuration (import), as the Data API may report the default values of the provider.
+func vectorServiceModel(service *dataAPIVectorService, prior *collectionVectorServiceModel) *collectionVectorServiceModel {
+	if service == nil {
+		return nil
+	}
+
+	model := &collectionVectorServiceModel{
+		Provider:  types.StringValue(service.Provider),
+		ModelName: stringValueOrNull(service.ModelName),
+	}
+	if len(service.Authentication) > 0 {
+		model.Authentication = service.Authentication
+	}
+	for k, v := range service.Parameters {
+		if prior != nil {
+			if _, ok := prior.Parameters[k]; !ok {
+				continue
+			}
+		}
+		if model.Parameters == nil {
+			model.Parameters = map[string]string{}
+		}
+		model.Parameters[k] = formatDataAPIParameter(v)
+	}
+	return model
+}
+
+func formatDataAPIParameter(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// validateVectorService checks a vector service against the embedding provider catalog of the database. Parameters are
+// converted to the types expected by the provider. The dimension of the vectors is returned, which is taken from the model
+// when the model generates vectors of a fixed dimension and no dimension is configured.
+func validateVectorService(providers map[string]dataAPIEmbeddingProvider, service *dataAPIVectorService, dimension *int64) (*int64, error) {
+	provider, ok := providers[service.Provider]
+	if !ok {
+		names := make([]string, 0, len(providers))
+		for name := range providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("embedding provider %q is not supported by the database, supported providers: %s", service.Provider, strings.Join(names, ", "))
+	}
+
+	parameters := provider.Parameters
+	if len(provider.Models) > 0 {
+		var model *dataAPIEmbeddingProviderModel
+		names := make([]string, 0, len(provider.Models))
+		for i := range provider.Models {
+			names = append(names, provider.Models[i].Name)
+			if provider.Models[i].Name == service.ModelName {
+				model = &provider.Models[i]
+			}
+		}
+		if model == nil {
+			return nil, fmt.Errorf("model %q is not supported by embedding provider %s, supported models: %s", service.ModelName, service.Provider, strings.Join(names, ", "))
+		}
+		if model.VectorDimension != nil {
+			if dimension == nil {
+				dimension = model.VectorDimension
+			} else if *dimension != *model.VectorDimension {
+				return nil, fmt.Errorf("model %s generates vectors of dimension %d, but the configured dimension is %d", model.Name, *model.VectorDimension, *dimension)
+			}
+		}
+		parameters = append(append([]dataAPIEmbeddingProviderParameter{}, parameters...), model.Parameters...)
+	}
+	if dimension == nil {
+		return nil, fmt.Errorf("\"dimension\" must be set, model %q of embedding provider %s does not have a fixed dimension", service.ModelName, service.Provider)
+	}
+
+	if len(service.Authentication) > 0 {
+		sharedSecret := provider.SupportedAuthentication["SHARED_SECRET"]
+		if !sharedSecret.Enabled {
+			return nil, fmt.Errorf("embedding provider %s does not support authentication with shared secrets", service.Provider)
+		}
+		accepted := map[string]bool{}
+		for _, token := range sharedSecret.Tokens {
+			accepted[token.Accepted] = true
+		}
+		for key := range service.Authentication {
+			if len(accepted) > 0 && !accepted[key] {
+				return nil, fmt.Errorf("authentication %q is not supported by embedding provider %s", key, service.Provider)
+			}
+		}
+	} else if !provider.SupportedAuthentication["NONE"].Enabled && !provider.SupportedAuthentication["HEADER"].Enabled {
+		return nil, fmt.Errorf("embedding provider %s requires \"authentication\" with a shared secret", service.Provider)
+	}
+
+	definitions := map[string]dataAPIEmbeddingProviderParameter{}
+	for _, p := range parameters {
+		definitions[p.Name] = p
+		if _, ok := service.Parameters[p.Name]; !ok && p.Required && p.DefaultValue == "" {
+			return nil, fmt.Errorf("parameter %q is required by embedding provider %s", p.Name, service.Provider)
+		}
+	}
+	for name, value := range service.Parameters {
+		definition, ok := definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("parameter %q is not supported by embedding provider %s", name, service.Provider)
+		}
+		stringValue := fmt.Sprint(value)
+		switch definition.Type {
+		case "number":
+			if _, err := strconv.ParseFloat(stringValue, 64); err != nil {
+				return nil, fmt.Errorf("parameter %q must be a number, got %q", name, stringValue)
+			}
+			service.Parameters[name] = json.Number(stringValue)
+		case "boolean":
+			b, err := strconv.ParseBool(stringValue)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %q must be a boolean, got %q", name, stringValue)
+			}
+			service.Parameters[name] = b
+		}
+	}
+	return dimension, nil
+}
+
+func collectionID(databaseID, keyspaceName, collectionName string) string {
+	return fmt.Sprintf("%s/%s/%s", databaseID, keyspaceName, collectionName)
+}
+
+// parseCollectionID returns the database ID, keyspace and collection name from an ID of the form database_id/keyspace/name.
+func parseCollectionID(id string) (string, string, string, error) {
+	idParts := strings.Split(id, "/")
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid collection id format %q: expected database_id/keyspace/name", id)
+	}
+	return idParts[0], idParts[1], idParts[2], nil
+}
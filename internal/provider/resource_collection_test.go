@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollection(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCollectionConfiguration(databaseID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_collection.vectors", "keyspace", "default_keyspace"),
+					resource.TestCheckResourceAttr("astra_collection.vectors", "vector.metric", "dot_product"),
+					resource.TestCheckResourceAttr("astra_collection.vectorize", "vector.dimension", "1024"),
+				),
+			},
+			{
+				ResourceName:            "astra_collection.vectors",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "timeouts"},
+			},
+		},
+	})
+}
+
+func testAccCollectionConfiguration(databaseID string) string {
+	return fmt.Sprintf(`
+resource "astra_collection" "vectors" {
+  database_id = "%s"
+  name        = "tf_vectors"
+  vector = {
+    dimension = 5
+    metric    = "dot_product"
+  }
+  indexing = {
+    deny = ["blob"]
+  }
+  default_id_type     = "uuidv7"
+  deletion_protection = false
+}
+
+resource "astra_collection" "vectorize" {
+  database_id = "%s"
+  name        = "tf_vectorize"
+  vector = {
+    service = {
+      provider   = "nvidia"
+      model_name = "NV-Embed-QA"
+    }
+  }
+  deletion_protection = false
+}
+`, databaseID, databaseID)
+}
+
+func TestParseCollectionID(t *testing.T) {
+	databaseID, keyspace, collection, err := parseCollectionID("48bfc13b-c1a5-48db-b70f-b6ef9709872b/default_keyspace/docs")
+	assert.NoError(t, err)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b", databaseID)
+	assert.Equal(t, "default_keyspace", keyspace)
+	assert.Equal(t, "docs", collection)
+
+	_, _, _, err = parseCollectionID("48bfc13b-c1a5-48db-b70f-b6ef9709872b/docs")
+	assert.Error(t, err)
+	_, _, _, err = parseCollectionID("48bfc13b-c1a5-48db-b70f-b6ef9709872b//docs")
+	assert.Error(t, err)
+}
+
+func TestCollectionDefinition(t *testing.T) {
+	model := collectionResourceModel{
+		Name: types.StringValue("docs"),
+		Vector: &collectionVectorModel{
+			Dimension: types.Int64Value(1536),
+			Metric:    types.StringValue("cosine"),
+		},
+		Indexing:      &collectionIndexingModel{Allow: []string{"title"}},
+		DefaultIDType: types.StringNull(),
+	}
+	collection := model.collection()
+	assert.Equal(t, "docs", collection.Name)
+	assert.Equal(t, int64(1536), *collection.Options.Vector.Dimension)
+	assert.Equal(t, "cosine", collection.Options.Vector.Metric)
+	assert.Equal(t, []string{"title"}, collection.Options.Indexing.Allow)
+	assert.Nil(t, collection.Options.DefaultID)
+
+	// Reading the definition back must give the same model, so that no drift is reported
+	var read collectionResourceModel
+	read.setCollection(&collection)
+	assert.Equal(t, model.Vector, read.Vector)
+	assert.Equal(t, model.Indexing, read.Indexing)
+	assert.True(t, read.DefaultIDType.IsNull())
+
+	// A collection changed outside of Terraform
+	dimension := int64(768)
+	read.setCollection(&dataAPICollection{
+		Name: "docs",
+		Options: dataAPICollectionOptions{
+			Vector:    &dataAPICollectionVector{Dimension: &dimension},
+			DefaultID: &dataAPICollectionDefaultID{Type: "objectId"},
+		},
+	})
+	assert.Equal(t, types.Int64Value(768), read.Vector.Dimension)
+	assert.Equal(t, types.StringValue("cosine"), read.Vector.Metric)
+	assert.Nil(t, read.Indexing)
+	assert.Equal(t, types.StringValue("objectId"), read.DefaultIDType)
+}
+
+func TestValidateVectorService(t *testing.T) {
+	dimension := int64(1024)
+	providers := map[string]dataAPIEmbeddingProvider{
+		"nvidia": {
+			SupportedAuthentication: map[string]dataAPIEmbeddingProviderAuthentication{"NONE": {Enabled: true}},
+			Models:                  []dataAPIEmbeddingProviderModel{{Name: "NV-Embed-QA", VectorDimension: &dimension}},
+		},
+		"openai": {
+			SupportedAuthentication: map[string]dataAPIEmbeddingProviderAuthentication{
+				"HEADER":        {Enabled: true},
+				"SHARED_SECRET": {Enabled: true, Tokens: []dataAPIEmbeddingProviderToken{{Accepted: "providerKey", Forwarded: "Authorization"}}},
+			},
+			Parameters: []dataAPIEmbeddingProviderParameter{{Name: "organizationId", Type: "string"}},
+			Models: []dataAPIEmbeddingProviderModel{{
+				Name:       "text-embedding-3-small",
+				Parameters: []dataAPIEmbeddingProviderParameter{{Name: "vectorDimension", Type: "number", Required: true, DefaultValue: "1536"}},
+			}},
+		},
+		"azureOpenAI": {
+			SupportedAuthentication: map[string]dataAPIEmbeddingProviderAuthentication{
+				"SHARED_SECRET": {Enabled: true, Tokens: []dataAPIEmbeddingProviderToken{{Accepted: "providerKey"}}},
+			},
+			Parameters: []dataAPIEmbeddingProviderParameter{{Name: "resourceName", Type: "string", Required: true}},
+			Models:     []dataAPIEmbeddingProviderModel{{Name: "text-embedding-3-small"}},
+		},
+	}
+
+	// The dimension is taken from the model
+	service := &dataAPIVectorService{Provider: "nvidia", ModelName: "NV-Embed-QA"}
+	resolved, err := validateVectorService(providers, service, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024), *resolved)
+
+	_, err = validateVectorService(providers, service, &[]int64{768}[0])
+	assert.EqualError(t, err, "model NV-Embed-QA generates vectors of dimension 1024, but the configured dimension is 768")
+
+	_, err = validateVectorService(providers, &dataAPIVectorService{Provider: "cohere"}, nil)
+	assert.EqualError(t, err, `embedding provider "cohere" is not supported by the database, supported providers: azureOpenAI, nvidia, openai`)
+
+	_, err = validateVectorService(providers, &dataAPIVectorService{Provider: "openai", ModelName: "ada"}, nil)
+	assert.EqualError(t, err, `model "ada" is not supported by embedding provider openai, supported models: text-embedding-3-small`)
+
+	_, err = validateVectorService(providers, &dataAPIVectorService{Provider: "openai", ModelName: "text-embedding-3-small"}, nil)
+	assert.EqualError(t, err, `"dimension" must be set, model "text-embedding-3-small" of embedding provider openai does not have a fixed dimension`)
+
+	// Parameters are converted to the types of the catalog
+	dimension = 512
+	service = &dataAPIVectorService{
+		Provider:       "openai",
+		ModelName:      "text-embedding-3-small",
+		Authentication: map[string]string{"providerKey": "my-openai-key"},
+		Parameters:     map[string]interface{}{"vectorDimension": "512", "organizationId": "org-1"},
+	}
+	resolved, err = validateVectorService(providers, service, &dimension)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(512), *resolved)
+	assert.Equal(t, json.Number("512"), service.Parameters["vectorDimension"])
+	assert.Equal(t, "org-1", service.Parameters["organizationId"])
+
+	service.Parameters = map[string]interface{}{"vectorDimension": "large"}
+	_, err = validateVectorService(providers, service, &dimension)
+	assert.EqualError(t, err, `parameter "vectorDimension" must be a number, got "large"`)
+	service.Parameters = map[string]interface{}{"user": "me"}
+	_, err = validateVectorService(providers, service, &dimension)
+	assert.EqualError(t, err, `parameter "user" is not supported by embedding provider openai`)
+	service.Parameters = nil
+	service.Authentication = map[string]string{"apiKey": "my-openai-key"}
+	_, err = validateVectorService(providers, service, &dimension)
+	assert.EqualError(t, err, `authentication "apiKey" is not supported by embedding provider openai`)
+
+	service = &dataAPIVectorService{Provider: "azureOpenAI", ModelName: "text-embedding-3-small"}
+	_, err = validateVectorService(providers, service, &dimension)
+	assert.EqualError(t, err, `embedding provider azureOpenAI requires "authentication" with a shared secret`)
+	service.Authentication = map[string]string{"providerKey": "my-azure-key"}
+	_, err = validateVectorService(providers, service, &dimension)
+	assert.EqualError(t, err, `parameter "resourceName" is required by embedding provider azureOpenAI`)
+}
+
+func TestVectorServiceModel(t *testing.T) {
+	service := &dataAPIVectorService{
+		Provider:       "openai",
+		ModelName:      "text-embedding-3-small",
+		Authentication: map[string]string{"providerKey": "my-openai-key"},
+		Parameters:     map[string]interface{}{"vectorDimension": float64(512), "organizationId": "org-1"},
+	}
+
+	// Imported collections report all the parameters
+	model := vectorServiceModel(service, nil)
+	assert.Equal(t, types.StringValue("openai"), model.Provider)
+	assert.Equal(t, types.StringValue("text-embedding-3-small"), model.ModelName)
+	assert.Equal(t, map[string]string{"providerKey": "my-openai-key"}, model.Authentication)
+	assert.Equal(t, map[string]string{"vectorDimension": "512", "organizationId": "org-1"}, model.Parameters)
+
+	// Parameters which were not configured are ignored
+	model = vectorServiceModel(service, &collectionVectorServiceModel{Parameters: map[string]string{"organizationId": "org-2"}})
+	assert.Equal(t, map[string]string{"organizationId": "org-1"}, model.Parameters)
+	model = vectorServiceModel(service, &collectionVectorServiceModel{})
+	assert.Nil(t, model.Parameters)
+
+	assert.Nil(t, vectorServiceModel(nil, nil))
+}
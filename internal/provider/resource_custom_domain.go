@@ -0,0 +1,411 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+var (
+	_ resource.Resource                = &customDomainResource{}
+	_ resource.ResourceWithConfigure   = &customDomainResource{}
+	_ resource.ResourceWithImportState = &customDomainResource{}
+)
+
+func NewCustomDomainResource() resource.Resource {
+	return &customDomainResource{}
+}
+
+type customDomainResource struct {
+	clients *astraClients2
+}
+
+type customDomainResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	DatabaseID        types.String   `tfsdk:"database_id"`
+	DatacenterID      types.String   `tfsdk:"datacenter_id"`
+	Domain            types.String   `tfsdk:"domain"`
+	WaitForValidation types.Bool     `tfsdk:"wait_for_validation"`
+	Status            types.String   `tfsdk:"status"`
+	DNSRecords        types.List     `tfsdk:"dns_records"`
+	APIFQDN           types.String   `tfsdk:"api_fqdn"`
+	CQLFQDN           types.String   `tfsdk:"cql_fqdn"`
+	DashboardFQDN     types.String   `tfsdk:"dashboard_fqdn"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+var customDomainDNSRecordAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"type":  types.StringType,
+	"value": types.StringType,
+}
+
+// customDomain is a custom domain of a datacenter, as returned by the DevOps API.
+type customDomain struct {
+	Domain        string                  `json:"domain"`
+	Status        string                  `json:"status"`
+	DNSRecords    []customDomainDNSRecord `json:"dnsRecords"`
+	APIFQDN       string                  `json:"apiFQDN"`
+	CQLFQDN       string                  `json:"cqlFQDN"`
+	DashboardFQDN string                  `json:"dashboardFQDN"`
+}
+
+type customDomainDNSRecord struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+const (
+	customDomainStatusActive = "ACTIVE"
+	customDomainStatusFailed = "FAILED"
+)
+
+func (r *customDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_domain"
+}
+
+func (r *customDomainResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`astra_custom_domain` registers a custom domain for a datacenter of a database. The DNS records listed in `dns_records` must be created in the DNS zone of the domain for Astra to validate the domain. Once validated, secure connect bundles for the custom domain are returned by the `astra_secure_connect_bundle_url` data source.\n\n" +
+			"Custom domains can be imported with an ID of the form `database_id/datacenter_id/domain`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the custom domain, in the format `database_id/datacenter_id/domain`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database_id": schema.StringAttribute{
+				Description: "The ID of the Astra database.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegex, "must be a valid UUID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"datacenter_id": schema.StringAttribute{
+				Description: "The ID of the datacenter of the database, for example `48bfc13b-c1a5-48db-b70f-b6ef9709872b-1`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "The custom domain, for example `db.example.com`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_validation": schema.BoolAttribute{
+				Description: "Whether to wait for Astra to validate the domain. Set to `false` when the DNS records are created by the same Terraform configuration, as they can only be created once the domain is registered. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"status": schema.StringAttribute{
+				Description: "The validation status of the domain.",
+				Computed:    true,
+			},
+			"dns_records": schema.ListNestedAttribute{
+				Description: "The DNS records to create for Astra to validate the domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the record.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the record, for example `CNAME`.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The value of the record.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"api_fqdn": schema.StringAttribute{
+				Description: "The FQDN for API requests through the custom domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cql_fqdn": schema.StringAttribute{
+				Description: "The FQDN for CQL requests through the custom domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dashboard_fqdn": schema.StringAttribute{
+				Description: "The FQDN for the dashboard through the custom domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *customDomainResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+func (r *customDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan customDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 60*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID, datacenterID, domain := plan.DatabaseID.ValueString(), plan.DatacenterID.ValueString(), plan.Domain.ValueString()
+	if err := waitForDatabaseActive(ctx, r.clients.astraClient, databaseID); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to wait for database '%s' to be active", databaseID), err.Error())
+		return
+	}
+
+	body, statusCode, err := devopsRequest(ctx, r.clients.astraClient, http.MethodPost, customDomainsPath(databaseID, datacenterID), map[string]string{"domain": domain})
+	if err != nil {
+		resp.Diagnostics.AddError("Error registering custom domain", err.Error())
+		return
+	} else if statusCode >= 300 {
+		resp.Diagnostics.AddError("Error registering custom domain",
+			fmt.Sprintf("failed to register custom domain %s for datacenter %s, status code: %d, message: %s", domain, datacenterID, statusCode, string(body)))
+		return
+	}
+
+	// Save the registered custom domain right away, so that it is not lost if the refresh fails.
+	plan.ID = types.StringValue(customDomainID(databaseID, datacenterID, domain))
+	plan.Status = types.StringNull()
+	plan.DNSRecords = types.ListNull(types.ObjectType{AttrTypes: customDomainDNSRecordAttrTypes})
+	plan.APIFQDN = types.StringNull()
+	plan.CQLFQDN = types.StringNull()
+	plan.DashboardFQDN = types.StringNull()
+	if resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	cd, err := r.refresh(ctx, databaseID, datacenterID, domain, plan.WaitForValidation.ValueBool(), true, createTimeout)
+	if cd != nil {
+		resp.Diagnostics.Append(plan.setCustomDomain(ctx, cd)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error registering custom domain", err.Error())
+	}
+}
+
+func (r *customDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state customDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cd, err := getCustomDomain(ctx, r.clients, state.DatabaseID.ValueString(), state.DatacenterID.ValueString(), state.Domain.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading custom domain", err.Error())
+		return
+	} else if cd == nil {
+		// Custom domain not found. Remove from state.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setCustomDomain(ctx, cd)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only handles wait_for_validation and timeouts, all the other attributes force a new custom domain.
+func (r *customDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan customDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 60*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	cd, err := r.refresh(ctx, plan.DatabaseID.ValueString(), plan.DatacenterID.ValueString(), plan.Domain.ValueString(), plan.WaitForValidation.ValueBool(), false, updateTimeout)
+	if cd != nil {
+		resp.Diagnostics.Append(plan.setCustomDomain(ctx, cd)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating custom domain", err.Error())
+	}
+}
+
+func (r *customDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state customDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID, datacenterID, domain := state.DatabaseID.ValueString(), state.DatacenterID.ValueString(), state.Domain.ValueString()
+	body, statusCode, err := devopsRequest(ctx, r.clients.astraClient, http.MethodDelete, customDomainPath(databaseID, datacenterID, domain), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting custom domain", err.Error())
+	} else if statusCode >= 300 && statusCode != http.StatusNotFound {
+		resp.Diagnostics.AddError("Error deleting custom domain",
+			fmt.Sprintf("failed to delete custom domain %s of datacenter %s, status code: %d, message: %s", domain, datacenterID, statusCode, string(body)))
+	}
+}
+
+func (r *customDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	databaseID, datacenterID, domain, err := parseCustomDomainID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing custom domain", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_id"), databaseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("datacenter_id"), datacenterID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_validation"), true)...)
+}
+
+// refresh fetches the custom domain, waiting for it to be validated if requested. The last known state of the custom domain
+// is returned along with any error, so that the DNS records are saved even if the validation fails or times out. When the
+// custom domain was just registered, errors and a missing custom domain are retried as the API may not have caught up yet.
+func (r *customDomainResource) refresh(ctx context.Context, databaseID, datacenterID, domain string, wait, registered bool, timeout time.Duration) (*customDomain, error) {
+	var cd *customDomain
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		current, err := getCustomDomain(ctx, r.clients, databaseID, datacenterID, domain)
+		if err == nil && current == nil {
+			err = fmt.Errorf("custom domain %s not found for datacenter %s", domain, datacenterID)
+		}
+		if err != nil {
+			if registered {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+		cd = current
+
+		switch {
+		case !wait:
+			return nil
+		case strings.EqualFold(cd.Status, customDomainStatusActive):
+			return nil
+		case strings.EqualFold(cd.Status, customDomainStatusFailed):
+			return retry.NonRetryableError(fmt.Errorf("validation of custom domain %s failed, check the DNS records", domain))
+		default:
+			return retry.RetryableError(fmt.Errorf("waiting for custom domain %s to be validated, status: %s", domain, cd.Status))
+		}
+	})
+	return cd, err
+}
+
+func (m *customDomainResourceModel) setCustomDomain(ctx context.Context, cd *customDomain) (diags diag.Diagnostics) {
+	m.Status = types.StringValue(cd.Status)
+	m.APIFQDN = types.StringValue(cd.APIFQDN)
+	m.CQLFQDN = types.StringValue(cd.CQLFQDN)
+	m.DashboardFQDN = types.StringValue(cd.DashboardFQDN)
+
+	records := make([]customDomainDNSRecordModel, 0, len(cd.DNSRecords))
+	for _, record := range cd.DNSRecords {
+		records = append(records, customDomainDNSRecordModel{
+			Name:  types.StringValue(record.Name),
+			Type:  types.StringValue(record.Type),
+			Value: types.StringValue(record.Value),
+		})
+	}
+	m.DNSRecords, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: customDomainDNSRecordAttrTypes}, records)
+	return diags
+}
+
+type customDomainDNSRecordModel struct {
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+func customDomainsPath(databaseID, datacenterID string) string {
+	return fmt.Sprintf("v2/databases/%s/datacenters/%s/custom-domains", databaseID, datacenterID)
+}
+
+func customDomainPath(databaseID, datacenterID, domain string) string {
+	return customDomainsPath(databaseID, datacenterID) + "/" + url.PathEscape(domain)
+}
+
+// getCustomDomain returns the given custom domain, or nil if it doesn't exist.
+func getCustomDomain(ctx context.Context, clients *astraClients2, databaseID, datacenterID, domain string) (*customDomain, error) {
+	body, statusCode, err := devopsRequest(ctx, clients.astraClient, http.MethodGet, customDomainPath(databaseID, datacenterID, domain), nil)
+	if err != nil {
+		return nil, err
+	} else if statusCode == http.StatusNotFound {
+		return nil, nil
+	} else if statusCode >= 300 {
+		return nil, fmt.Errorf("failed to get custom domain %s of datacenter %s, status code: %d, message: %s", domain, datacenterID, statusCode, string(body))
+	}
+
+	var cd customDomain
+	if err := json.Unmarshal(body, &cd); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal custom domain %s: %w", domain, err)
+	}
+	return &cd, nil
+}
+
+func customDomainID(databaseID, datacenterID, domain string) string {
+	return fmt.Sprintf("%s/%s/%s", databaseID, datacenterID, domain)
+}
+
+// parseCustomDomainID returns the database ID, datacenter ID and domain from an ID of the form database_id/datacenter_id/domain.
+func parseCustomDomainID(id string) (string, string, string, error) {
+	idParts := strings.Split(id, "/")
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid custom domain id format %q: expected database_id/datacenter_id/domain", id)
+	}
+	return idParts[0], idParts[1], idParts[2], nil
+}
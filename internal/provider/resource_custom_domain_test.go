@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomDomain(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID", "ASTRA_TEST_DATACENTER_ID", "ASTRA_TEST_CUSTOM_DOMAIN")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+	datacenterID := os.Getenv("ASTRA_TEST_DATACENTER_ID")
+	domain := os.Getenv("ASTRA_TEST_CUSTOM_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomDomainConfiguration(databaseID, datacenterID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_custom_domain.domain", "domain", domain),
+					resource.TestCheckResourceAttrSet("astra_custom_domain.domain", "status"),
+					resource.TestCheckResourceAttrSet("astra_custom_domain.domain", "dns_records.#"),
+				),
+			},
+			{
+				ResourceName:            "astra_custom_domain.domain",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_validation", "timeouts"},
+			},
+		},
+	})
+}
+
+func testAccCustomDomainConfiguration(databaseID, datacenterID, domain string) string {
+	return fmt.Sprintf(`
+resource "astra_custom_domain" "domain" {
+  database_id         = "%s"
+  datacenter_id       = "%s"
+  domain              = "%s"
+  wait_for_validation = false
+}
+`, databaseID, datacenterID, domain)
+}
+
+func TestParseCustomDomainID(t *testing.T) {
+	databaseID, datacenterID, domain, err := parseCustomDomainID(customDomainID("48bfc13b-c1a5-48db-b70f-b6ef9709872b", "48bfc13b-c1a5-48db-b70f-b6ef9709872b-1", "db.example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b", databaseID)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b-1", datacenterID)
+	assert.Equal(t, "db.example.com", domain)
+
+	for _, id := range []string{"", "db", "db/dc", "db//example.com", "db/dc/example.com/extra"} {
+		_, _, _, err := parseCustomDomainID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestCustomDomainRefresh(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusNotFound)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"domain": "db.example.com", "status": "PENDING"}`))
+		}
+	}))
+	defer server.Close()
+
+	astraClient, err := astra.NewClientWithResponses(server.URL)
+	assert.NoError(t, err)
+	r := &customDomainResource{clients: &astraClients2{astraClient: astraClient}}
+
+	// a just registered custom domain is retried until it is found
+	cd, err := r.refresh(context.Background(), "db", "dc", "db.example.com", false, true, time.Minute)
+	assert.NoError(t, err)
+	if assert.NotNil(t, cd) {
+		assert.Equal(t, "PENDING", cd.Status)
+	}
+
+	// otherwise a missing custom domain fails right away
+	requests = 0
+	cd, err = r.refresh(context.Background(), "db", "dc", "db.example.com", false, false, time.Minute)
+	assert.Error(t, err)
+	assert.Nil(t, cd)
+	assert.Equal(t, 1, requests)
+}
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,6 +19,23 @@ import (
 var availableBYOKCloudProviders = []string{
 	"aws",
 	"gcp",
+	"azure",
+}
+
+// customerKeySources are the attributes which can specify the Customer Key, exactly one of them must be configured.
+var customerKeySources = []string{"key_id", "aws", "gcp", "azure"}
+
+// azureKMS is the Azure Key Vault payload of a Customer Key, which is not modelled by the generated client.
+type azureKMS struct {
+	KeyID    *string `json:"keyID,omitempty"`
+	Region   *string `json:"region,omitempty"`
+	TenantID *string `json:"tenantID,omitempty"`
+}
+
+// externalKMS is the create Customer Key request with support for Azure.
+type externalKMS struct {
+	astra.ExternalKMS
+	Azure *azureKMS `json:"azure,omitempty"`
 }
 
 func resourceCustomerKey() *schema.Resource {
@@ -28,7 +47,9 @@ func resourceCustomerKey() *schema.Resource {
 					   "Please see https://docs.datastax.com/en/astra-db-serverless/administration/delete-customer-keys.html for more information.",
 		CreateContext: resourceCustomerKeyCreate,
 		ReadContext:   resourceCustomerKeyRead,
+		UpdateContext: resourceCustomerKeyUpdate,
 		DeleteContext: resourceCustomerKeyDelete,
+		CustomizeDiff: resourceCustomerKeyCustomizeDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -37,31 +58,116 @@ func resourceCustomerKey() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			// Required
 			"cloud_provider": {
-				Description:      "The cloud provider where the Customer Key exists (Currently supported: aws, gcp)",
+				Description:      "The cloud provider where the Customer Key exists (Currently supported: aws, gcp, azure)",
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
 				ValidateFunc:     validation.StringInSlice(availableBYOKCloudProviders, true),
 				DiffSuppressFunc: ignoreCase,
 			},
-			"key_id": {
-				Description:      "Customer Key ID. This is cloud provider specific.",
-				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-			},
 			"region": {
 				Description:     "Region in which the Customer Key exists.",
 				Type:            schema.TypeString,
 				Required:        true,
 				ForceNew:        true,
 			},
+			// Optional, exactly one of key_id, aws, gcp or azure
+			"key_id": {
+				Description:  "Customer Key ID. This is cloud provider specific. Not supported for azure. Changing the key rotates the Customer Key in place.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: customerKeySources,
+				Deprecated:   "Use the cloud provider specific aws, gcp or azure block instead.",
+			},
+			"aws": {
+				Description:  "AWS KMS key, `cloud_provider` must be aws.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				ExactlyOneOf: customerKeySources,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_arn": {
+							Description:  "ARN of the KMS key, for example \"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab\".",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(awsKMSKeyARNRegex, "must be an AWS KMS key ARN"),
+						},
+					},
+				},
+			},
+			"gcp": {
+				Description:  "GCP Cloud KMS key, `cloud_provider` must be gcp.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				ExactlyOneOf: customerKeySources,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_name": {
+							Description:  "Resource name of the key, for example \"projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key\".",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(gcpKMSKeyNameRegex, "must be a GCP Cloud KMS key resource name"),
+						},
+					},
+				},
+			},
+			"azure": {
+				Description:  "Azure Key Vault key, `cloud_provider` must be azure.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				ExactlyOneOf: customerKeySources,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vault_uri": {
+							Description:  "URI of the Key Vault, for example \"https://my-vault.vault.azure.net\".",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(azureKeyVaultURIRegex, "must be an Azure Key Vault URI"),
+						},
+						"key_name": {
+							Description: "Name of the key in the Key Vault.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"key_version": {
+							Description: "Version of the key. If omitted, the current version of the key is used.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"tenant_id": {
+							Description:  "UUID of the Azure tenant of the Key Vault. The tenant is not part of the Customer Key ID, so it is not compared for imported keys.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(uuidRegex, "must be a valid UUID"),
+							DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+								// imported keys have no tenant in the state
+								return oldValue == "" && d.Id() != ""
+							},
+						},
+					},
+				},
+			},
 			// Computed
 			"organization_id": {
 				Description:    "The Astra organization ID (this is derived from the token used to create the Customer Key).",
 				Type:           schema.TypeString,
 				Computed:       true,
 			},
+			"database_ids": {
+				Description: "IDs of the databases in the cloud provider and region of the Customer Key, which are encrypted with the key. When the key is rotated, the plan shows these databases as they will pick up the new key.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -69,7 +175,6 @@ func resourceCustomerKey() *schema.Resource {
 func resourceCustomerKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 	cloudProvider := d.Get("cloud_provider").(string)
-	keyId := d.Get("key_id").(string)
 	region := d.Get("region").(string)
 	// Determine the orgId from the current context
 	orgId, err := getOrgId(ctx, client)
@@ -78,21 +183,18 @@ func resourceCustomerKeyCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// build the create Key request
-	createKeyReq := &astra.ExternalKMS{
-		OrgId: &orgId,
-	}
-	if strings.EqualFold("aws", cloudProvider) {
-		createKeyReq.Aws = buildAWSKms(region, keyId)
-	} else if strings.EqualFold("gcp", cloudProvider) {
-		createKeyReq.Gcp = buildGCPKms(region, keyId)
+	createKeyReq, keyId, err := buildCustomerKeyRequest(d, cloudProvider, region)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	// create the Customer Key
-	resp, err := client.CreateKeyWithResponse(ctx, *createKeyReq)
+	createKeyReq.OrgId = &orgId
+	// create the Customer Key, the generated client does not support Azure
+	respBody, statusCode, err := devopsRequest(ctx, client, http.MethodPost, "v2/kms", createKeyReq)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if resp.StatusCode() != http.StatusCreated {
-		return diag.Errorf("Unexpected error creating Customer Key. Status: %d, Message: %s", resp.StatusCode(), string(resp.Body))
+	if statusCode != http.StatusCreated {
+		return diag.Errorf("Unexpected error creating Customer Key. Status: %d, Message: %s", statusCode, string(respBody))
 	}
 	// set the data
 	if err := setCustomerKeyData(d, orgId, cloudProvider, region, keyId); err != nil {
@@ -102,16 +204,67 @@ func resourceCustomerKeyCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceCustomerKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
 	id := d.Id()
 
 	orgId, cloudProvider, region, keyId, err := parseCustomerKeyId(id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	setCustomerKeyData(d, orgId, cloudProvider, region, keyId)
+
+	customerKeys, err := listCustomerKeys(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteKeyId, found := findCustomerKey(customerKeys, cloudProvider, region)
+	if !found {
+		tflog.Warn(ctx, fmt.Sprintf("Customer Key for provider %s, region %s not found, removing from state", cloudProvider, region))
+		d.SetId("")
+		return nil
+	}
+	if remoteKeyId != keyId {
+		tflog.Info(ctx, fmt.Sprintf("Customer Key for provider %s, region %s changed from %s to %s", cloudProvider, region, keyId, remoteKeyId))
+	}
+	if err := setCustomerKeyData(d, orgId, cloudProvider, region, remoteKeyId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	databaseIds, err := listCustomerKeyDatabases(ctx, client, cloudProvider, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database_ids", databaseIds); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
+func resourceCustomerKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	orgId := d.Get("organization_id").(string)
+	cloudProvider := d.Get("cloud_provider").(string)
+	region := d.Get("region").(string)
+
+	if d.HasChanges(customerKeySources...) {
+		rotateKeyReq, keyId, err := buildCustomerKeyRequest(d, cloudProvider, region)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rotateKeyReq.OrgId = &orgId
+		respBody, statusCode, err := devopsRequest(ctx, client, http.MethodPost, "v2/kms", rotateKeyReq)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if statusCode != http.StatusOK && statusCode != http.StatusCreated {
+			return diag.Errorf("Unable to rotate Customer Key for provider %s, region %s to %s. If the key can't be rotated, open a Support ticket. Status: %d, Message: %s", cloudProvider, region, keyId, statusCode, string(respBody))
+		}
+		if err := setCustomerKeyData(d, orgId, cloudProvider, region, keyId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceCustomerKeyRead(ctx, d, meta)
+}
+
 func resourceCustomerKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Delete not yet supported via DevOps API
 	return diag.Diagnostics{
@@ -123,6 +276,170 @@ func resourceCustomerKeyDelete(ctx context.Context, d *schema.ResourceData, meta
 	}
 }
 
+// resourceCustomerKeyCustomizeDiff checks that the configured key matches the cloud provider.
+func resourceCustomerKeyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("cloud_provider") {
+		return nil
+	}
+	cloudProvider := strings.ToLower(d.Get("cloud_provider").(string))
+	config := d.GetRawConfig()
+	for _, block := range []string{"aws", "gcp", "azure"} {
+		value := config.GetAttr(block)
+		if block != cloudProvider && value.IsKnown() && !value.IsNull() && value.LengthInt() > 0 {
+			return fmt.Errorf("the %q block is not supported with cloud_provider %q, use the %q block instead", block, cloudProvider, cloudProvider)
+		}
+	}
+	keyID := config.GetAttr("key_id")
+	if !keyID.IsNull() && keyID.IsKnown() {
+		if err := validateCustomerKeyID(cloudProvider, keyID.AsString()); err != nil {
+			return err
+		}
+	}
+
+	// on rotation, the key attributes which are not configured and the databases are recomputed
+	if d.Id() != "" && d.HasChanges(customerKeySources...) {
+		for _, k := range []string{"key_id", cloudProvider, "database_ids"} {
+			if config.GetAttr(k).IsNull() {
+				if err := d.SetNewComputed(k); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateCustomerKeyID validates the format of a Customer Key ID for the given cloud provider.
+func validateCustomerKeyID(cloudProvider, keyId string) error {
+	switch cloudProvider {
+	case "aws":
+		if !awsKMSKeyARNRegex.MatchString(keyId) {
+			return fmt.Errorf("invalid key_id %q: must be an AWS KMS key ARN", keyId)
+		}
+	case "gcp":
+		if !gcpKMSKeyNameRegex.MatchString(keyId) {
+			return fmt.Errorf("invalid key_id %q: must be a GCP Cloud KMS key resource name", keyId)
+		}
+	case "azure":
+		return errors.New("key_id is not supported with cloud_provider \"azure\", use the \"azure\" block instead")
+	default:
+		return fmt.Errorf("unsupported cloud_provider %q for Customer Keys, expected one of: %s", cloudProvider, strings.Join(availableBYOKCloudProviders, ", "))
+	}
+	return nil
+}
+
+// buildCustomerKeyRequest returns the create Customer Key request and the Customer Key ID for the configured key.
+func buildCustomerKeyRequest(d *schema.ResourceData, cloudProvider, region string) (*externalKMS, string, error) {
+	req := &externalKMS{}
+	switch strings.ToLower(cloudProvider) {
+	case "aws":
+		keyId := d.Get("aws.0.key_arn").(string)
+		if !d.GetRawConfig().GetAttr("key_id").IsNull() {
+			keyId = d.Get("key_id").(string)
+		}
+		req.Aws = buildAWSKms(region, keyId)
+		return req, keyId, nil
+	case "gcp":
+		keyId := d.Get("gcp.0.key_name").(string)
+		if !d.GetRawConfig().GetAttr("key_id").IsNull() {
+			keyId = d.Get("key_id").(string)
+		}
+		req.Gcp = buildGCPKms(region, keyId)
+		return req, keyId, nil
+	case "azure":
+		if len(d.Get("azure").([]interface{})) == 0 {
+			return nil, "", errors.New("the \"azure\" block is required with cloud_provider \"azure\"")
+		}
+		keyId := azureKeyID(d.Get("azure.0.vault_uri").(string), d.Get("azure.0.key_name").(string), d.Get("azure.0.key_version").(string))
+		tenantId := d.Get("azure.0.tenant_id").(string)
+		req.Azure = &azureKMS{
+			KeyID:    &keyId,
+			Region:   &region,
+			TenantID: &tenantId,
+		}
+		return req, keyId, nil
+	}
+	return nil, "", fmt.Errorf("unsupported cloud_provider %q for Customer Keys, expected one of: %s", cloudProvider, strings.Join(availableBYOKCloudProviders, ", "))
+}
+
+// findCustomerKey returns the Customer Key ID for the cloud provider and region, as returned by listCustomerKeys.
+func findCustomerKey(customerKeys []map[string]interface{}, cloudProvider, region string) (string, bool) {
+	for _, key := range customerKeys {
+		if strings.EqualFold(cloudProvider, key["cloud_provider"].(string)) && region == key["region"].(string) {
+			return key["key_id"].(string), true
+		}
+	}
+	return "", false
+}
+
+// listCustomerKeyDatabases returns the IDs of the databases with a datacenter in the cloud provider and region.
+func listCustomerKeyDatabases(ctx context.Context, client *astra.ClientWithResponses, cloudProvider, region string) ([]string, error) {
+	resp, err := client.ListDatabasesWithResponse(ctx, &astra.ListDatabasesParams{})
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Error fetching databases. Status: %d, Message: %s", resp.StatusCode(), string(resp.Body))
+	}
+	databaseIds := []string{}
+	for _, db := range astra.DatabaseSlice(resp.JSON200) {
+		if db.Info.Datacenters == nil {
+			continue
+		}
+		for _, dc := range *db.Info.Datacenters {
+			if strings.EqualFold(string(dc.CloudProvider), cloudProvider) && dc.Region == region {
+				databaseIds = append(databaseIds, db.Id)
+				break
+			}
+		}
+	}
+	sort.Strings(databaseIds)
+	return databaseIds, nil
+}
+
+// azureKeyID returns the Key Vault key identifier, for example https://my-vault.vault.azure.net/keys/my-key/version.
+func azureKeyID(vaultURI, keyName, keyVersion string) string {
+	keyId := strings.TrimSuffix(vaultURI, "/") + "/keys/" + keyName
+	if keyVersion != "" {
+		keyId += "/" + keyVersion
+	}
+	return keyId
+}
+
+// parseAzureKeyID returns the vault URI, key name and key version of a Key Vault key identifier.
+func parseAzureKeyID(keyId string) (string, string, string, error) {
+	vaultURI, keyPath, ok := strings.Cut(keyId, "/keys/")
+	parts := strings.Split(keyPath, "/")
+	if !ok || !azureKeyVaultURIRegex.MatchString(vaultURI) || parts[0] == "" || len(parts) > 2 {
+		return "", "", "", fmt.Errorf("invalid Azure key id %q: expected https://<vault>.vault.azure.net/keys/<key_name>[/<key_version>]", keyId)
+	}
+	if len(parts) == 2 {
+		return vaultURI, parts[0], parts[1], nil
+	}
+	return vaultURI, parts[0], "", nil
+}
+
+// flattenCustomerKeyBlock returns the cloud provider specific block for a Customer Key ID.
+func flattenCustomerKeyBlock(cloudProvider, keyId, tenantId string) (string, []map[string]interface{}, error) {
+	switch strings.ToLower(cloudProvider) {
+	case "aws":
+		return "aws", []map[string]interface{}{{"key_arn": keyId}}, nil
+	case "gcp":
+		return "gcp", []map[string]interface{}{{"key_name": keyId}}, nil
+	case "azure":
+		vaultURI, keyName, keyVersion, err := parseAzureKeyID(keyId)
+		if err != nil {
+			return "", nil, err
+		}
+		return "azure", []map[string]interface{}{{
+			"vault_uri":   vaultURI,
+			"key_name":    keyName,
+			"key_version": keyVersion,
+			"tenant_id":   tenantId,
+		}}, nil
+	}
+	return "", nil, fmt.Errorf("unsupported cloud_provider %q for Customer Keys", cloudProvider)
+}
+
 func buildAWSKms(region, keyId string) *astra.AWSKMS {
 	return &astra.AWSKMS{
 		KeyID: &keyId,
@@ -150,6 +467,13 @@ func setCustomerKeyData(d *schema.ResourceData, orgId, cloudProvider, region, ke
 	if err := d.Set("key_id", keyId); err != nil {
 		return err
 	}
+	block, value, err := flattenCustomerKeyBlock(cloudProvider, keyId, d.Get("azure.0.tenant_id").(string))
+	if err != nil {
+		return err
+	}
+	if err := d.Set(block, value); err != nil {
+		return err
+	}
 
 	// generate the resource ID
 	// format: <organization_id>/cloudProvider/<cloud_provider>/region/<region>/keyId/<key_id>
@@ -2,6 +2,8 @@ package provider
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCustomerKeyIdParser(t *testing.T) {
@@ -27,4 +29,55 @@ func TestCustomerKeyIdParser(t *testing.T) {
 		t.Logf("Key ID parsed from Customer Key ID: \"%s\", expected \"%s\"", keyId, "arn:aws:kms:us-east-1:388533891461:key/85e37e2b-d897-49f0-9d18-3c0daf4a7ff5")
 		t.Fail()
 	}
-}
\ No newline at end of file
+}
+
+func TestAzureKeyID(t *testing.T) {
+	keyId := azureKeyID("https://my-vault.vault.azure.net/", "my-key", "0123456789abcdef")
+	assert.Equal(t, "https://my-vault.vault.azure.net/keys/my-key/0123456789abcdef", keyId)
+
+	vaultURI, keyName, keyVersion, err := parseAzureKeyID(keyId)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://my-vault.vault.azure.net", vaultURI)
+	assert.Equal(t, "my-key", keyName)
+	assert.Equal(t, "0123456789abcdef", keyVersion)
+
+	_, keyName, keyVersion, err = parseAzureKeyID(azureKeyID("https://my-vault.vault.azure.net", "my-key", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, "my-key", keyName)
+	assert.Equal(t, "", keyVersion)
+
+	for _, id := range []string{"my-key", "https://my-vault.vault.azure.net/keys/", "https://example.com/keys/my-key", "https://my-vault.vault.azure.net/keys/my-key/v1/extra"} {
+		_, _, _, err := parseAzureKeyID(id)
+		assert.Error(t, err, id)
+	}
+
+	orgId, cloudProvider, region, parsedKeyId, err := parseCustomerKeyId("28b7d281-a2ae-4e5b-bc3f-9f80df5f5223/cloudProvider/azure/region/eastus/keyId/" + keyId)
+	assert.NoError(t, err)
+	assert.Equal(t, "28b7d281-a2ae-4e5b-bc3f-9f80df5f5223", orgId)
+	assert.Equal(t, "azure", cloudProvider)
+	assert.Equal(t, "eastus", region)
+	assert.Equal(t, keyId, parsedKeyId)
+}
+
+func TestValidateCustomerKeyID(t *testing.T) {
+	assert.NoError(t, validateCustomerKeyID("aws", "arn:aws:kms:us-east-1:388533891461:key/85e37e2b-d897-49f0-9d18-3c0daf4a7ff5"))
+	assert.Error(t, validateCustomerKeyID("aws", "projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key"))
+	assert.NoError(t, validateCustomerKeyID("gcp", "projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key"))
+	assert.NoError(t, validateCustomerKeyID("gcp", "projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key/cryptoKeyVersions/1"))
+	assert.Error(t, validateCustomerKeyID("gcp", "arn:aws:kms:us-east-1:388533891461:key/85e37e2b-d897-49f0-9d18-3c0daf4a7ff5"))
+	assert.Error(t, validateCustomerKeyID("azure", "https://my-vault.vault.azure.net/keys/my-key"))
+	assert.Error(t, validateCustomerKeyID("oracle", "key"))
+}
+
+func TestFindCustomerKey(t *testing.T) {
+	customerKeys := []map[string]interface{}{
+		{"organization_id": "28b7d281-a2ae-4e5b-bc3f-9f80df5f5223", "cloud_provider": "AWS", "region": "us-east-1", "key_id": "arn:aws:kms:us-east-1:388533891461:key/85e37e2b-d897-49f0-9d18-3c0daf4a7ff5"},
+		{"organization_id": "28b7d281-a2ae-4e5b-bc3f-9f80df5f5223", "cloud_provider": "gcp", "region": "us-east1", "key_id": "projects/my-project/locations/us-east1/keyRings/my-ring/cryptoKeys/my-key"},
+	}
+	keyId, found := findCustomerKey(customerKeys, "aws", "us-east-1")
+	assert.True(t, found)
+	assert.Equal(t, "arn:aws:kms:us-east-1:388533891461:key/85e37e2b-d897-49f0-9d18-3c0daf4a7ff5", keyId)
+
+	_, found = findCustomerKey(customerKeys, "aws", "us-west-2")
+	assert.False(t, found)
+}
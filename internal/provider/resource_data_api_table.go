@@ -0,0 +1,652 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &dataAPITableResource{}
+	_ resource.ResourceWithConfigure   = &dataAPITableResource{}
+	_ resource.ResourceWithImportState = &dataAPITableResource{}
+	_ resource.ResourceWithIdentity    = &dataAPITableResource{}
+	_ resource.ResourceWithModifyPlan  = &dataAPITableResource{}
+)
+
+func NewDataAPITableResource() resource.Resource {
+	return &dataAPITableResource{}
+}
+
+type dataAPITableResource struct {
+	clients *astraClients2
+}
+
+type dataAPITableResourceModel struct {
+	ID                 types.String                       `tfsdk:"id"`
+	DatabaseID         types.String                       `tfsdk:"database_id"`
+	Region             types.String                       `tfsdk:"region"`
+	Keyspace           types.String                       `tfsdk:"keyspace"`
+	Name               types.String                       `tfsdk:"name"`
+	Columns            map[string]dataAPITableColumnModel `tfsdk:"columns"`
+	PrimaryKey         *dataAPITablePrimaryKeyModel       `tfsdk:"primary_key"`
+	DeletionProtection types.Bool                         `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value                     `tfsdk:"timeouts"`
+}
+
+type dataAPITableColumnModel struct {
+	Type      types.String                  `tfsdk:"type"`
+	KeyType   types.String                  `tfsdk:"key_type"`
+	ValueType types.String                  `tfsdk:"value_type"`
+	Dimension types.Int64                   `tfsdk:"dimension"`
+	Service   *collectionVectorServiceModel `tfsdk:"service"`
+}
+
+type dataAPITablePrimaryKeyModel struct {
+	PartitionBy   []string                      `tfsdk:"partition_by"`
+	PartitionSort []dataAPITableSortColumnModel `tfsdk:"partition_sort"`
+}
+
+type dataAPITableSortColumnModel struct {
+	Column types.String `tfsdk:"column"`
+	Order  types.String `tfsdk:"order"`
+}
+
+func (r *dataAPITableResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_api_table"
+}
+
+func (r *dataAPITableResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`astra_data_api_table` provides a table resource managed through the Data API at the data endpoint of the database. Unlike `astra_table`, it supports vector columns, including vectors generated server side by an embedding provider (vectorize). Columns can be added and removed, and the embedding provider of vector columns changed, without replacing the table.\n\n" +
+			"Tables can be imported with an ID of the form `database_id/keyspace/name`, or with an `import` block using the `database_id`, `keyspace` and `name` identity attributes (Terraform v1.12.0 and later).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the table, in the format `database_id/keyspace/name`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database_id": schema.StringAttribute{
+				Description: "Astra database to create the table in.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegex, "must be a valid UUID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "Region of the datacenter to send Data API requests to. Defaults to any healthy datacenter of the database.",
+				Optional:    true,
+			},
+			"keyspace": schema.StringAttribute{
+				Description: fmt.Sprintf("Keyspace to create the table in. Defaults to `%s`.", defaultDataAPIKeyspace),
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultDataAPIKeyspace),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyspaceNameRegex, "invalid keyspace name"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Table name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(collectionNameRegex, "invalid table name"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"columns": schema.MapNestedAttribute{
+				Description: "Columns of the table, keyed by column name. Adding or removing columns, and changing the `service` of vector columns, is done in place. Changing the type of a column forces a new table.",
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Data API type of the column, for example `text`, `int`, `timestamp`, `map`, `set`, `list` or `vector`.",
+							Required:    true,
+						},
+						"key_type": schema.StringAttribute{
+							Description: "Type of the keys of a `map` column.",
+							Optional:    true,
+						},
+						"value_type": schema.StringAttribute{
+							Description: "Type of the values of a `map`, `set` or `list` column.",
+							Optional:    true,
+						},
+						"dimension": schema.Int64Attribute{
+							Description: "Number of dimensions of a `vector` column. Required unless `service` is set with a model which generates vectors of a fixed dimension.",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
+						},
+						"service": schema.SingleNestedAttribute{
+							Description: "Embedding provider used to generate the vectors of a `vector` column server side (vectorize). The configuration is validated against the embedding providers supported by the database.",
+							Optional:    true,
+							Attributes:  vectorServiceAttributes(),
+						},
+					},
+				},
+			},
+			"primary_key": schema.SingleNestedAttribute{
+				Description: "Primary key of the table.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"partition_by": schema.ListAttribute{
+						Description: "Columns of the partition key.",
+						Required:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+					"partition_sort": schema.ListNestedAttribute{
+						Description: "Clustering columns, in order.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"column": schema.StringAttribute{
+									Description: "Name of the clustering column.",
+									Required:    true,
+								},
+								"order": schema.StringAttribute{
+									Description: "Clustering order, `asc` or `desc`. Defaults to `asc`.",
+									Optional:    true,
+									Computed:    true,
+									Default:     stringdefault.StaticString("asc"),
+									Validators: []validator.String{
+										stringvalidator.OneOf("asc", "desc"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether or not to allow Terraform to destroy the table, and the data it contains. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes or replaces the table will fail. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *dataAPITableResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"database_id": identityschema.StringAttribute{
+				Description:       "The ID of the Astra database.",
+				RequiredForImport: true,
+			},
+			"keyspace": identityschema.StringAttribute{
+				Description:       "The keyspace of the table.",
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The table name.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *dataAPITableResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+func (r *dataAPITableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dataAPITableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	databaseID := plan.DatabaseID.ValueString()
+	if err := waitForDatabaseActive(ctx, r.clients.astraClient, databaseID); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to wait for database '%s' to be active", databaseID), err.Error())
+		return
+	}
+
+	dataAPI, _, err := r.clients.restClientPool.getDataAPI(ctx, databaseID, plan.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating table", err.Error())
+		return
+	}
+
+	table := plan.table()
+	if err := validateTableVectorServices(ctx, dataAPI, r.clients.token, table.Definition.Columns); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("columns"), "Invalid vector service", err.Error())
+		return
+	}
+	if err := dataAPI.createTable(ctx, r.clients.token, plan.Keyspace.ValueString(), table); err != nil {
+		resp.Diagnostics.AddError("Error creating table", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(collectionID(databaseID, plan.Keyspace.ValueString(), plan.Name.ValueString()))
+	plan.setComputedDimensions(table.Definition.Columns)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
+}
+
+func (r *dataAPITableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dataAPITableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID := state.DatabaseID.ValueString()
+	dataAPI, _, err := r.clients.restClientPool.getDataAPI(ctx, databaseID, state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading table", err.Error())
+		return
+	}
+	table, err := dataAPI.findTable(ctx, r.clients.token, state.Keyspace.ValueString(), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading table", err.Error())
+		return
+	} else if table == nil {
+		// Table not found. Remove from state.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.setTable(table)
+	state.ID = types.StringValue(collectionID(databaseID, state.Keyspace.ValueString(), state.Name.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, state.identity())...)
+}
+
+// ModifyPlan forces a new table when the type of an existing column changes, as the Data API can only add and drop columns.
+func (r *dataAPITableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state dataAPITableResourceModel
+	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
+		// Some values are not known yet, Update rejects type changes in that case
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if changed := changedColumnTypes(state.table().Definition.Columns, plan.table().Definition.Columns); len(changed) > 0 {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("columns"))
+	}
+}
+
+func (r *dataAPITableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state dataAPITableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	current := state.table().Definition.Columns
+	desired := plan.table().Definition.Columns
+	if changed := changedColumnTypes(current, desired); len(changed) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("columns"), "Error updating table",
+			fmt.Sprintf("the type of column(s) %s cannot be changed in place", strings.Join(changed, ", ")))
+		return
+	}
+	alterations := tableAlterations(current, desired)
+
+	dataAPI, _, err := r.clients.restClientPool.getDataAPI(ctx, plan.DatabaseID.ValueString(), plan.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating table", err.Error())
+		return
+	}
+
+	// Validate all the new vector services before altering the table, so that the update is not applied partially
+	vectorizedColumns := map[string]dataAPITableColumn{}
+	for name, column := range alterations.add {
+		vectorizedColumns[name] = column
+	}
+	for name := range alterations.addVectorize {
+		vectorizedColumns[name] = desired[name]
+	}
+	if err := validateTableVectorServices(ctx, dataAPI, r.clients.token, vectorizedColumns); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("columns"), "Invalid vector service", err.Error())
+		return
+	}
+	for name := range alterations.add {
+		alterations.add[name] = vectorizedColumns[name]
+		desired[name] = vectorizedColumns[name]
+	}
+	for name := range alterations.addVectorize {
+		alterations.addVectorize[name] = vectorizedColumns[name].Service
+	}
+
+	keyspace, name := plan.Keyspace.ValueString(), plan.Name.ValueString()
+	operations := []struct {
+		operation string
+		columns   interface{}
+		size      int
+	}{
+		{"dropVectorize", alterations.dropVectorize, len(alterations.dropVectorize)},
+		{"drop", alterations.drop, len(alterations.drop)},
+		{"add", alterations.add, len(alterations.add)},
+		{"addVectorize", alterations.addVectorize, len(alterations.addVectorize)},
+	}
+	for _, op := range operations {
+		if op.size == 0 {
+			continue
+		}
+		if err := dataAPI.alterTable(ctx, r.clients.token, keyspace, name, op.operation, op.columns); err != nil {
+			resp.Diagnostics.AddError("Error updating table", err.Error())
+			return
+		}
+	}
+
+	plan.setComputedDimensions(desired)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
+}
+
+func (r *dataAPITableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dataAPITableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Error deleting table", "\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_data_api_table")
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	dataAPI, _, err := r.clients.restClientPool.getDataAPI(ctx, state.DatabaseID.ValueString(), state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting table", err.Error())
+		return
+	}
+	if err := dataAPI.dropTable(ctx, r.clients.token, state.Keyspace.ValueString(), state.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error deleting table", err.Error())
+	}
+}
+
+// ImportState accepts either an ID of the form database_id/keyspace/name, or the resource identity.
+func (r *dataAPITableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var databaseID, keyspaceName, tableName string
+	if req.ID != "" {
+		var err error
+		databaseID, keyspaceName, tableName, err = parseCollectionID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing table", err.Error())
+			return
+		}
+	} else {
+		var identity collectionResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		databaseID = identity.DatabaseID.ValueString()
+		keyspaceName = identity.Keyspace.ValueString()
+		tableName = identity.Name.ValueString()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), collectionID(databaseID, keyspaceName, tableName))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_id"), databaseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("keyspace"), keyspaceName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), tableName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+}
+
+// identity returns the resource identity, which has the same attributes as the identity of collections.
+func (m dataAPITableResourceModel) identity() collectionResourceIdentityModel {
+	return collectionResourceIdentityModel{
+		DatabaseID: m.DatabaseID,
+		Keyspace:   m.Keyspace,
+		Name:       m.Name,
+	}
+}
+
+// table returns the Data API definition of the table described by the model.
+func (m dataAPITableResourceModel) table() dataAPITable {
+	table := dataAPITable{
+		Name: m.Name.ValueString(),
+		Definition: dataAPITableDefinition{
+			Columns: map[string]dataAPITableColumn{},
+		},
+	}
+	for name, c := range m.Columns {
+		table.Definition.Columns[name] = dataAPITableColumn{
+			Type:      c.Type.ValueString(),
+			KeyType:   c.KeyType.ValueString(),
+			ValueType: c.ValueType.ValueString(),
+			Dimension: knownInt64Pointer(c.Dimension),
+			Service:   c.Service.service(),
+		}
+	}
+	if m.PrimaryKey != nil {
+		table.Definition.PrimaryKey.PartitionBy = m.PrimaryKey.PartitionBy
+		for _, c := range m.PrimaryKey.PartitionSort {
+			table.Definition.PrimaryKey.PartitionSort = append(table.Definition.PrimaryKey.PartitionSort, dataAPITableSortColumn{
+				Column:    c.Column.ValueString(),
+				Ascending: c.Order.ValueString() != "desc",
+			})
+		}
+	}
+	return table
+}
+
+// setTable updates the model with the definition of the table returned by the Data API, so that changes made outside of
+// Terraform are detected.
+func (m *dataAPITableResourceModel) setTable(table *dataAPITable) {
+	columns := make(map[string]dataAPITableColumnModel, len(table.Definition.Columns))
+	for name, c := range table.Definition.Columns {
+		prior, hasPrior := m.Columns[name]
+		column := dataAPITableColumnModel{
+			Type:      types.StringValue(c.Type),
+			KeyType:   stringValueOrNull(c.KeyType),
+			ValueType: stringValueOrNull(c.ValueType),
+			Dimension: types.Int64PointerValue(c.Dimension),
+		}
+		// The Data API reports types in lower case, keep the configured case to avoid spurious differences
+		if hasPrior {
+			if strings.EqualFold(prior.Type.ValueString(), c.Type) {
+				column.Type = prior.Type
+			}
+			if strings.EqualFold(prior.KeyType.ValueString(), c.KeyType) {
+				column.KeyType = prior.KeyType
+			}
+			if strings.EqualFold(prior.ValueType.ValueString(), c.ValueType) {
+				column.ValueType = prior.ValueType
+			}
+		}
+		var priorService *collectionVectorServiceModel
+		if hasPrior {
+			priorService = prior.Service
+		}
+		column.Service = vectorServiceModel(c.Service, priorService)
+		columns[name] = column
+	}
+	m.Columns = columns
+
+	m.PrimaryKey = &dataAPITablePrimaryKeyModel{
+		PartitionBy: table.Definition.PrimaryKey.PartitionBy,
+	}
+	for _, c := range table.Definition.PrimaryKey.PartitionSort {
+		order := "asc"
+		if !c.Ascending {
+			order = "desc"
+		}
+		m.PrimaryKey.PartitionSort = append(m.PrimaryKey.PartitionSort, dataAPITableSortColumnModel{
+			Column: types.StringValue(c.Column),
+			Order:  types.StringValue(order),
+		})
+	}
+}
+
+// setComputedDimensions sets the dimensions which were not configured, from the definitions sent to the Data API.
+func (m *dataAPITableResourceModel) setComputedDimensions(columns map[string]dataAPITableColumn) {
+	for name, c := range m.Columns {
+		if c.Dimension.IsUnknown() {
+			c.Dimension = types.Int64PointerValue(columns[name].Dimension)
+			m.Columns[name] = c
+		}
+	}
+}
+
+// validateTableVectorServices validates the vector services of the given columns against the embedding provider catalog
+// of the database, and updates the columns with the resolved dimensions and typed parameters.
+func validateTableVectorServices(ctx context.Context, dataAPI *dataAPIClient, token string, columns map[string]dataAPITableColumn) error {
+	var providers map[string]dataAPIEmbeddingProvider
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		column := columns[name]
+		if column.Service == nil {
+			continue
+		}
+		if providers == nil {
+			var err error
+			if providers, err = dataAPI.findEmbeddingProviders(ctx, token); err != nil {
+				return fmt.Errorf("failed to fetch embedding providers: %w", err)
+			}
+		}
+		dimension, err := validateVectorService(providers, column.Service, column.Dimension)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", name, err)
+		}
+		column.Dimension = dimension
+		columns[name] = column
+	}
+	return nil
+}
+
+// changedColumnTypes returns the names of the columns whose type changes, in alphabetical order.
+func changedColumnTypes(current, desired map[string]dataAPITableColumn) []string {
+	changed := []string{}
+	for name, d := range desired {
+		c, ok := current[name]
+		if !ok {
+			continue
+		}
+		if !strings.EqualFold(c.Type, d.Type) || !strings.EqualFold(c.KeyType, d.KeyType) || !strings.EqualFold(c.ValueType, d.ValueType) ||
+			(d.Dimension != nil && c.Dimension != nil && *d.Dimension != *c.Dimension) {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// dataAPITableAlterations are the alterTable operations needed to go from one table definition to another.
+type dataAPITableAlterations struct {
+	drop          []string
+	add           map[string]dataAPITableColumn
+	dropVectorize []string
+	addVectorize  map[string]*dataAPIVectorService
+}
+
+func tableAlterations(current, desired map[string]dataAPITableColumn) dataAPITableAlterations {
+	alterations := dataAPITableAlterations{
+		drop:          []string{},
+		add:           map[string]dataAPITableColumn{},
+		dropVectorize: []string{},
+		addVectorize:  map[string]*dataAPIVectorService{},
+	}
+	for name := range current {
+		if _, ok := desired[name]; !ok {
+			alterations.drop = append(alterations.drop, name)
+		}
+	}
+	for name, d := range desired {
+		c, ok := current[name]
+		if !ok {
+			alterations.add[name] = d
+			continue
+		}
+		if reflect.DeepEqual(c.Service, d.Service) {
+			continue
+		}
+		if c.Service != nil {
+			alterations.dropVectorize = append(alterations.dropVectorize, name)
+		}
+		if d.Service != nil {
+			alterations.addVectorize[name] = d.Service
+		}
+	}
+	sort.Strings(alterations.drop)
+	sort.Strings(alterations.dropVectorize)
+	return alterations
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataAPITable(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataAPITableConfiguration(databaseID, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_data_api_table.products", "columns.embedding.dimension", "1024"),
+				),
+			},
+			{
+				// add a column in place
+				Config: testAccDataAPITableConfiguration(databaseID, `
+    price = {
+      type = "double"
+    }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_data_api_table.products", "columns.price.type", "double"),
+				),
+			},
+			{
+				ResourceName:            "astra_data_api_table.products",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "timeouts"},
+			},
+		},
+	})
+}
+
+func testAccDataAPITableConfiguration(databaseID, extraColumns string) string {
+	return fmt.Sprintf(`
+resource "astra_data_api_table" "products" {
+  database_id = "%s"
+  name        = "tf_products"
+  columns = {
+    id = {
+      type = "text"
+    }
+    created_at = {
+      type = "timestamp"
+    }
+    tags = {
+      type       = "set"
+      value_type = "text"
+    }
+    embedding = {
+      type = "vector"
+      service = {
+        provider   = "nvidia"
+        model_name = "NV-Embed-QA"
+      }
+    }%s
+  }
+  primary_key = {
+    partition_by = ["id"]
+    partition_sort = [
+      { column = "created_at", order = "desc" },
+    ]
+  }
+  deletion_protection = false
+}
+`, databaseID, extraColumns)
+}
+
+func TestDataAPITablePartitionSortJSON(t *testing.T) {
+	primaryKey := dataAPITablePrimaryKey{
+		PartitionBy: []string{"id"},
+		PartitionSort: dataAPITablePartitionSort{
+			{Column: "created_at", Ascending: false},
+			{Column: "b", Ascending: true},
+			{Column: "a", Ascending: true},
+		},
+	}
+	data, err := json.Marshal(primaryKey)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"partitionBy":["id"],"partitionSort":{"created_at":-1,"b":1,"a":1}}`, string(data))
+
+	var decoded dataAPITablePrimaryKey
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, primaryKey, decoded)
+
+	var unsorted dataAPITablePrimaryKey
+	assert.NoError(t, json.Unmarshal([]byte(`{"partitionBy":["id"]}`), &unsorted))
+	assert.Nil(t, unsorted.PartitionSort)
+	assert.Error(t, json.Unmarshal([]byte(`{"partitionBy":["id"],"partitionSort":["a"]}`), &unsorted))
+}
+
+func TestTableAlterations(t *testing.T) {
+	dimension := int64(1024)
+	nvidia := &dataAPIVectorService{Provider: "nvidia", ModelName: "NV-Embed-QA", Parameters: map[string]interface{}{}}
+	openai := &dataAPIVectorService{Provider: "openai", ModelName: "text-embedding-3-small", Parameters: map[string]interface{}{}}
+	current := map[string]dataAPITableColumn{
+		"id":        {Type: "text"},
+		"legacy":    {Type: "int"},
+		"embedding": {Type: "vector", Dimension: &dimension, Service: nvidia},
+		"summary":   {Type: "vector", Dimension: &dimension},
+	}
+	desired := map[string]dataAPITableColumn{
+		"id":        {Type: "TEXT"},
+		"price":     {Type: "double"},
+		"embedding": {Type: "vector", Dimension: &dimension, Service: openai},
+		"summary":   {Type: "vector", Service: nvidia},
+	}
+
+	assert.Empty(t, changedColumnTypes(current, desired))
+	alterations := tableAlterations(current, desired)
+	assert.Equal(t, []string{"legacy"}, alterations.drop)
+	assert.Equal(t, map[string]dataAPITableColumn{"price": {Type: "double"}}, alterations.add)
+	assert.Equal(t, []string{"embedding"}, alterations.dropVectorize)
+	assert.Equal(t, map[string]*dataAPIVectorService{"embedding": openai, "summary": nvidia}, alterations.addVectorize)
+
+	// unchanged table
+	alterations = tableAlterations(current, current)
+	assert.Empty(t, alterations.drop)
+	assert.Empty(t, alterations.add)
+	assert.Empty(t, alterations.dropVectorize)
+	assert.Empty(t, alterations.addVectorize)
+
+	otherDimension := int64(768)
+	desired["id"] = dataAPITableColumn{Type: "uuid"}
+	desired["embedding"] = dataAPITableColumn{Type: "vector", Dimension: &otherDimension}
+	assert.Equal(t, []string{"embedding", "id"}, changedColumnTypes(current, desired))
+}
+
+func TestDataAPITableDefinition(t *testing.T) {
+	model := dataAPITableResourceModel{
+		Name: types.StringValue("products"),
+		Columns: map[string]dataAPITableColumnModel{
+			"id": {Type: types.StringValue("TEXT"), KeyType: types.StringNull(), ValueType: types.StringNull(), Dimension: types.Int64Null()},
+			"attributes": {
+				Type:      types.StringValue("map"),
+				KeyType:   types.StringValue("text"),
+				ValueType: types.StringValue("int"),
+				Dimension: types.Int64Null(),
+			},
+			"embedding": {
+				Type:      types.StringValue("vector"),
+				KeyType:   types.StringNull(),
+				ValueType: types.StringNull(),
+				Dimension: types.Int64Unknown(),
+				Service:   &collectionVectorServiceModel{Provider: types.StringValue("nvidia"), ModelName: types.StringValue("NV-Embed-QA")},
+			},
+		},
+		PrimaryKey: &dataAPITablePrimaryKeyModel{
+			PartitionBy: []string{"id"},
+			PartitionSort: []dataAPITableSortColumnModel{
+				{Column: types.StringValue("attributes"), Order: types.StringValue("desc")},
+			},
+		},
+	}
+
+	table := model.table()
+	assert.Equal(t, "products", table.Name)
+	assert.Equal(t, dataAPITableColumn{Type: "map", KeyType: "text", ValueType: "int"}, table.Definition.Columns["attributes"])
+	assert.Nil(t, table.Definition.Columns["embedding"].Dimension)
+	assert.Equal(t, dataAPITablePartitionSort{{Column: "attributes", Ascending: false}}, table.Definition.PrimaryKey.PartitionSort)
+
+	// The dimension is resolved from the embedding model
+	dimension := int64(1024)
+	table.Definition.Columns["embedding"] = dataAPITableColumn{Type: "vector", Dimension: &dimension, Service: table.Definition.Columns["embedding"].Service}
+	model.setComputedDimensions(table.Definition.Columns)
+	assert.Equal(t, types.Int64Value(1024), model.Columns["embedding"].Dimension)
+
+	// Reading the table back must give the same model, so that no drift is reported
+	expected := model.Columns
+	table.Definition.Columns["id"] = dataAPITableColumn{Type: "text"}
+	model.setTable(&table)
+	assert.Equal(t, expected, model.Columns)
+	assert.Equal(t, []dataAPITableSortColumnModel{{Column: types.StringValue("attributes"), Order: types.StringValue("desc")}}, model.PrimaryKey.PartitionSort)
+}
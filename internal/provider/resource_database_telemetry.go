@@ -0,0 +1,457 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                     = &databaseTelemetryResource{}
+	_ resource.ResourceWithConfigure        = &databaseTelemetryResource{}
+	_ resource.ResourceWithImportState      = &databaseTelemetryResource{}
+	_ resource.ResourceWithConfigValidators = &databaseTelemetryResource{}
+)
+
+func NewDatabaseTelemetryResource() resource.Resource {
+	return &databaseTelemetryResource{}
+}
+
+type databaseTelemetryResource struct {
+	clients *astraClients2
+}
+
+type databaseTelemetryResourceModel struct {
+	ID                 types.String               `tfsdk:"id"`
+	DatabaseID         types.String               `tfsdk:"database_id"`
+	CredentialsVersion types.Int64                `tfsdk:"credentials_version"`
+	PrometheusRemote   *telemetryPrometheusRemote `tfsdk:"prometheus_remote"`
+	Datadog            *telemetryDatadog          `tfsdk:"datadog"`
+	Splunk             *telemetrySplunk           `tfsdk:"splunk"`
+	Kafka              *telemetryKafka            `tfsdk:"kafka"`
+	Pulsar             *telemetryPulsar           `tfsdk:"pulsar"`
+}
+
+// telemetryConfig is the body of the DevOps API telemetry endpoint. The destinations are shared with the resource model,
+// the write-only credentials are only set when the configuration is sent to Astra.
+type telemetryConfig struct {
+	PrometheusRemote *telemetryPrometheusRemote `json:"prometheus_remote,omitempty"`
+	Datadog          *telemetryDatadog          `json:"datadog,omitempty"`
+	Splunk           *telemetrySplunk           `json:"splunk,omitempty"`
+	Kafka            *telemetryKafka            `json:"kafka,omitempty"`
+	Pulsar           *telemetryPulsar           `json:"pulsar,omitempty"`
+}
+
+type telemetryPrometheusRemote struct {
+	Endpoint     *string `tfsdk:"endpoint" json:"endpoint"`
+	AuthStrategy *string `tfsdk:"auth_strategy" json:"auth_strategy"`
+	User         *string `tfsdk:"user" json:"user,omitempty"`
+	Password     *string `tfsdk:"password" json:"password,omitempty"`
+	Token        *string `tfsdk:"token" json:"token,omitempty"`
+}
+
+type telemetryDatadog struct {
+	APIKey *string `tfsdk:"api_key" json:"api_key"`
+	Site   *string `tfsdk:"site" json:"site,omitempty"`
+}
+
+type telemetrySplunk struct {
+	Endpoint   *string `tfsdk:"endpoint" json:"endpoint"`
+	Index      *string `tfsdk:"index" json:"index"`
+	Token      *string `tfsdk:"token" json:"token"`
+	Source     *string `tfsdk:"source" json:"source,omitempty"`
+	SourceType *string `tfsdk:"source_type" json:"sourcetype,omitempty"`
+}
+
+type telemetryKafka struct {
+	BootstrapServers []string            `tfsdk:"bootstrap_servers" json:"bootstrap_servers"`
+	Topic            *string             `tfsdk:"topic" json:"topic"`
+	SecurityProtocol *string             `tfsdk:"security_protocol" json:"security_protocol,omitempty"`
+	Sasl             *telemetryKafkaSasl `tfsdk:"sasl" json:"sasl,omitempty"`
+}
+
+type telemetryKafkaSasl struct {
+	Mechanism *string `tfsdk:"mechanism" json:"mechanism"`
+	Username  *string `tfsdk:"username" json:"username"`
+	Password  *string `tfsdk:"password" json:"password"`
+}
+
+type telemetryPulsar struct {
+	Endpoint             *string `tfsdk:"endpoint" json:"endpoint"`
+	Topic                *string `tfsdk:"topic" json:"topic"`
+	AuthStrategy         *string `tfsdk:"auth_strategy" json:"auth_strategy"`
+	Token                *string `tfsdk:"token" json:"token,omitempty"`
+	AuthName             *string `tfsdk:"auth_name" json:"auth_name,omitempty"`
+	OAuth2CredentialsURL *string `tfsdk:"oauth2_credentials_url" json:"oauth2_credentials_url,omitempty"`
+	OAuth2IssuerURL      *string `tfsdk:"oauth2_issuer_url" json:"oauth2_issuer_url,omitempty"`
+	OAuth2Audience       *string `tfsdk:"oauth2_audience" json:"oauth2_audience,omitempty"`
+	OAuth2Scope          *string `tfsdk:"oauth2_scope" json:"oauth2_scope,omitempty"`
+}
+
+func (r *databaseTelemetryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_telemetry"
+}
+
+// writeOnlyCredential returns the schema of a credential attribute. Credentials are write-only, they are sent to Astra but
+// never stored in the Terraform state, which requires Terraform v1.11.0 or later.
+func writeOnlyCredential(description string, required bool) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description + " Write-only, change `credentials_version` to send updated credentials.",
+		Required:    required,
+		Optional:    !required,
+		Sensitive:   true,
+		WriteOnly:   true,
+	}
+}
+
+func (r *databaseTelemetryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`astra_database_telemetry` configures the export of the metrics of a database to an external observability system. " +
+			"At least one destination must be configured. Credentials are write-only attributes, which requires Terraform v1.11.0 or later.\n\n" +
+			"The telemetry configuration can be imported with the database ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the telemetry configuration, which is the database ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database_id": schema.StringAttribute{
+				Description: "Astra database to export the metrics of.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegex, "must be a valid UUID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"credentials_version": schema.Int64Attribute{
+				Description: "Version of the write-only credentials. As changes to write-only attributes are not detected by Terraform, increment it to send updated credentials to Astra.",
+				Optional:    true,
+			},
+			"prometheus_remote": schema.SingleNestedAttribute{
+				Description: "Export metrics to a Prometheus remote write endpoint.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						Description: "URL of the remote write endpoint.",
+						Required:    true,
+					},
+					"auth_strategy": schema.StringAttribute{
+						Description: "Authentication of the endpoint, `basic` (with `user` and `password`) or `bearer` (with `token`).",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("basic", "bearer"),
+						},
+					},
+					"user": schema.StringAttribute{
+						Description: "User name for `basic` authentication.",
+						Optional:    true,
+					},
+					"password": writeOnlyCredential("Password for `basic` authentication.", false),
+					"token":    writeOnlyCredential("Token for `bearer` authentication.", false),
+				},
+			},
+			"datadog": schema.SingleNestedAttribute{
+				Description: "Export metrics to Datadog.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"api_key": writeOnlyCredential("Datadog API key.", true),
+					"site": schema.StringAttribute{
+						Description: "Datadog site, for example `datadoghq.eu`.",
+						Optional:    true,
+					},
+				},
+			},
+			"splunk": schema.SingleNestedAttribute{
+				Description: "Export metrics to a Splunk HTTP Event Collector.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						Description: "URL of the HTTP Event Collector.",
+						Required:    true,
+					},
+					"index": schema.StringAttribute{
+						Description: "Splunk index to write the metrics to.",
+						Required:    true,
+					},
+					"token": writeOnlyCredential("HTTP Event Collector token.", true),
+					"source": schema.StringAttribute{
+						Description: "Source of the events.",
+						Optional:    true,
+					},
+					"source_type": schema.StringAttribute{
+						Description: "Source type of the events.",
+						Optional:    true,
+					},
+				},
+			},
+			"kafka": schema.SingleNestedAttribute{
+				Description: "Export metrics to a Kafka topic.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"bootstrap_servers": schema.ListAttribute{
+						Description: "Kafka bootstrap servers.",
+						Required:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+					"topic": schema.StringAttribute{
+						Description: "Kafka topic to write the metrics to.",
+						Required:    true,
+					},
+					"security_protocol": schema.StringAttribute{
+						Description: "Security protocol, for example `SASL_SSL`.",
+						Optional:    true,
+					},
+					"sasl": schema.SingleNestedAttribute{
+						Description: "SASL authentication.",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"mechanism": schema.StringAttribute{
+								Description: "SASL mechanism, for example `PLAIN` or `SCRAM-SHA-512`.",
+								Required:    true,
+							},
+							"username": schema.StringAttribute{
+								Description: "SASL user name.",
+								Required:    true,
+							},
+							"password": writeOnlyCredential("SASL password.", true),
+						},
+					},
+				},
+			},
+			"pulsar": schema.SingleNestedAttribute{
+				Description: "Export metrics to a Pulsar topic.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						Description: "URL of the Pulsar service.",
+						Required:    true,
+					},
+					"topic": schema.StringAttribute{
+						Description: "Pulsar topic to write the metrics to.",
+						Required:    true,
+					},
+					"auth_strategy": schema.StringAttribute{
+						Description: "Authentication of the Pulsar service, `token` or `oauth2`.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("token", "oauth2"),
+						},
+					},
+					"token": writeOnlyCredential("Token for `token` authentication.", false),
+					"auth_name": schema.StringAttribute{
+						Description: "Name of the authentication plugin.",
+						Optional:    true,
+					},
+					"oauth2_credentials_url": schema.StringAttribute{
+						Description: "OAuth2 credentials URL for `oauth2` authentication.",
+						Optional:    true,
+					},
+					"oauth2_issuer_url": schema.StringAttribute{
+						Description: "OAuth2 issuer URL for `oauth2` authentication.",
+						Optional:    true,
+					},
+					"oauth2_audience": schema.StringAttribute{
+						Description: "OAuth2 audience for `oauth2` authentication.",
+						Optional:    true,
+					},
+					"oauth2_scope": schema.StringAttribute{
+						Description: "OAuth2 scope for `oauth2` authentication.",
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *databaseTelemetryResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("prometheus_remote"),
+			path.MatchRoot("datadog"),
+			path.MatchRoot("splunk"),
+			path.MatchRoot("kafka"),
+			path.MatchRoot("pulsar"),
+		),
+	}
+}
+
+func (r *databaseTelemetryResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+func (r *databaseTelemetryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, config databaseTelemetryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databaseID := plan.DatabaseID.ValueString()
+	if err := waitForDatabaseActive(ctx, r.clients.astraClient, databaseID); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to wait for database '%s' to be active", databaseID), err.Error())
+		return
+	}
+	// The credentials are only available in the configuration
+	if err := setTelemetryConfig(ctx, r.clients, databaseID, config.telemetryConfig()); err != nil {
+		resp.Diagnostics.AddError("Error configuring database telemetry", err.Error())
+		return
+	}
+
+	plan.ID = plan.DatabaseID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *databaseTelemetryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state databaseTelemetryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, found, err := getTelemetryConfig(ctx, r.clients, state.DatabaseID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading database telemetry", err.Error())
+		return
+	} else if !found || config.empty() {
+		// Telemetry not configured, or database not found. Remove from state.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.setTelemetryConfig(config)
+	state.ID = state.DatabaseID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update sends the whole configuration, as the DevOps API replaces the telemetry configuration of the database.
+func (r *databaseTelemetryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, config databaseTelemetryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := setTelemetryConfig(ctx, r.clients, plan.DatabaseID.ValueString(), config.telemetryConfig()); err != nil {
+		resp.Diagnostics.AddError("Error configuring database telemetry", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete clears the telemetry configuration, the DevOps API does not have a separate endpoint to disable telemetry.
+func (r *databaseTelemetryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state databaseTelemetryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setTelemetryConfig(ctx, r.clients, state.DatabaseID.ValueString(), telemetryConfig{})
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting database telemetry", err.Error())
+	}
+}
+
+func (r *databaseTelemetryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_id"), req.ID)...)
+}
+
+func (m databaseTelemetryResourceModel) telemetryConfig() telemetryConfig {
+	return telemetryConfig{
+		PrometheusRemote: m.PrometheusRemote,
+		Datadog:          m.Datadog,
+		Splunk:           m.Splunk,
+		Kafka:            m.Kafka,
+		Pulsar:           m.Pulsar,
+	}
+}
+
+// setTelemetryConfig updates the model with the telemetry configuration returned by Astra. The credentials returned by
+// Astra, if any, are ignored as write-only attributes must not be stored in the state.
+func (m *databaseTelemetryResourceModel) setTelemetryConfig(config telemetryConfig) {
+	m.PrometheusRemote = config.PrometheusRemote
+	if m.PrometheusRemote != nil {
+		m.PrometheusRemote.Password = nil
+		m.PrometheusRemote.Token = nil
+	}
+	m.Datadog = config.Datadog
+	if m.Datadog != nil {
+		m.Datadog.APIKey = nil
+	}
+	m.Splunk = config.Splunk
+	if m.Splunk != nil {
+		m.Splunk.Token = nil
+	}
+	m.Kafka = config.Kafka
+	if m.Kafka != nil && m.Kafka.Sasl != nil {
+		m.Kafka.Sasl.Password = nil
+	}
+	m.Pulsar = config.Pulsar
+	if m.Pulsar != nil {
+		m.Pulsar.Token = nil
+	}
+}
+
+func (c telemetryConfig) empty() bool {
+	return c.PrometheusRemote == nil && c.Datadog == nil && c.Splunk == nil && c.Kafka == nil && c.Pulsar == nil
+}
+
+func telemetryPath(databaseID string) string {
+	return fmt.Sprintf("v2/databases/%s/telemetry/metrics", databaseID)
+}
+
+// getTelemetryConfig returns the telemetry configuration of a database. The returned flag is false if the database
+// doesn't exist.
+func getTelemetryConfig(ctx context.Context, clients *astraClients2, databaseID string) (telemetryConfig, bool, error) {
+	var config telemetryConfig
+	body, statusCode, err := devopsRequest(ctx, clients.astraClient, http.MethodGet, telemetryPath(databaseID), nil)
+	if err != nil {
+		return config, false, err
+	} else if statusCode == http.StatusNotFound {
+		return config, false, nil
+	} else if statusCode >= 300 {
+		return config, false, fmt.Errorf("failed to get telemetry configuration of database %s, status code: %d, message: %s", databaseID, statusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &config); err != nil {
+		return config, false, fmt.Errorf("failed to unmarshal telemetry configuration of database %s: %w", databaseID, err)
+	}
+	return config, true, nil
+}
+
+func setTelemetryConfig(ctx context.Context, clients *astraClients2, databaseID string, config telemetryConfig) error {
+	body, statusCode, err := devopsRequest(ctx, clients.astraClient, http.MethodPost, telemetryPath(databaseID), config)
+	if err != nil {
+		return err
+	} else if statusCode >= 300 {
+		return fmt.Errorf("failed to configure telemetry of database %s, status code: %d, message: %s", databaseID, statusCode, string(body))
+	}
+	return nil
+}
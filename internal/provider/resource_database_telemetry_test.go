@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseTelemetry(t *testing.T) {
+	checkRequiredTestVars(t, "ASTRA_TEST_DATABASE_ID", "ASTRA_TEST_DATADOG_API_KEY")
+	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
+	apiKey := os.Getenv("ASTRA_TEST_DATADOG_API_KEY")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseTelemetryConfiguration(databaseID, apiKey, "datadoghq.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_database_telemetry.telemetry", "datadog.site", "datadoghq.com"),
+					resource.TestCheckNoResourceAttr("astra_database_telemetry.telemetry", "datadog.api_key"),
+				),
+			},
+			{
+				Config: testAccDatabaseTelemetryConfiguration(databaseID, apiKey, "datadoghq.eu"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_database_telemetry.telemetry", "datadog.site", "datadoghq.eu"),
+				),
+			},
+			{
+				ResourceName:      "astra_database_telemetry.telemetry",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccDatabaseTelemetryConfiguration(databaseID, apiKey, site string) string {
+	return fmt.Sprintf(`
+resource "astra_database_telemetry" "telemetry" {
+  database_id = "%s"
+  datadog = {
+    api_key = "%s"
+    site    = "%s"
+  }
+}
+`, databaseID, apiKey, site)
+}
+
+func TestTelemetryConfig(t *testing.T) {
+	body := `{
+  "prometheus_remote": {"endpoint": "https://prometheus.example.com/api/v1/write", "auth_strategy": "basic", "user": "astra", "password": "secret"},
+  "splunk": {"endpoint": "https://splunk.example.com:8088", "index": "astra", "token": "secret", "sourcetype": "astradb-metrics"},
+  "kafka": {"bootstrap_servers": ["kafka-1:9092"], "topic": "astra-metrics", "sasl": {"mechanism": "PLAIN", "username": "astra", "password": "secret"}}
+}`
+	var config telemetryConfig
+	assert.NoError(t, json.Unmarshal([]byte(body), &config))
+
+	// Credentials must not be stored in the state
+	var model databaseTelemetryResourceModel
+	model.setTelemetryConfig(config)
+	assert.Equal(t, "astra", *model.PrometheusRemote.User)
+	assert.Nil(t, model.PrometheusRemote.Password)
+	assert.Equal(t, "astradb-metrics", *model.Splunk.SourceType)
+	assert.Nil(t, model.Splunk.Token)
+	assert.Equal(t, []string{"kafka-1:9092"}, model.Kafka.BootstrapServers)
+	assert.Equal(t, "astra", *model.Kafka.Sasl.Username)
+	assert.Nil(t, model.Kafka.Sasl.Password)
+	assert.Nil(t, model.Datadog)
+	assert.Nil(t, model.Pulsar)
+
+	assert.True(t, telemetryConfig{}.empty())
+	data, err := json.Marshal(telemetryConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+}
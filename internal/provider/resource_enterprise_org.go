@@ -2,11 +2,20 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	enterpriseOrgOnDestroyDetach = "detach"
+	enterpriseOrgOnDestroyDelete = "delete"
 )
 
 func resourceEnterpriseOrg() *schema.Resource {
@@ -14,19 +23,19 @@ func resourceEnterpriseOrg() *schema.Resource {
 		Description:   "`enterprise_org` resource represents an Organization that is created under an Enterprise in Astra.",
 		CreateContext: resourceEnterpriseOrgCreate,
 		ReadContext:   resourceEnterpriseOrgRead,
+		UpdateContext: resourceEnterpriseOrgUpdate,
 		DeleteContext: resourceEnterpriseOrgDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceEnterpriseOrgImport,
 		},
 
 		Schema: map[string]*schema.Schema{
 			// Required
 			"name": {
-				Description: "Organization name",
+				Description: "Organization name. Changing the name renames the organization in place.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 			"email": {
 				Description: "Organization email address",
@@ -35,10 +44,22 @@ func resourceEnterpriseOrg() *schema.Resource {
 				ForceNew:    true,
 			},
 			"admin_user_id": {
-				Description: "UUID of the Astra user that will be the admin of the organization",
+				Description: "UUID of the Astra user that will be the admin of the organization. The admin user is not returned by Astra, so it is not compared for imported organizations.",
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					// imported organizations have no admin user in the state
+					return oldValue == "" && d.Id() != ""
+				},
+			},
+			// Optional
+			"on_destroy": {
+				Description:  "What to do with the organization when the resource is destroyed. \"detach\" only removes the organization from the Terraform state and leaves it in the Enterprise, \"delete\" deletes the organization. Defaults to \"detach\".",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      enterpriseOrgOnDestroyDetach,
+				ValidateFunc: validation.StringInSlice([]string{enterpriseOrgOnDestroyDetach, enterpriseOrgOnDestroyDelete}, false),
 			},
 			// Computed
 			"enterprise_id": {
@@ -106,35 +127,129 @@ func resourceEnterpriseOrgCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceEnterpriseOrgRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.Diagnostics{
-		diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  "Read of Enterprise Organizations not supported",
-			Detail:   "Read of Enterprise Organizations not supported.",
-		},
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	org, err := getEnterpriseOrg(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if org == nil {
+		// organization has been deleted outside of Terraform
+		d.SetId("")
+		return nil
+	}
+	if err := setEnterpriseOrgData(d, org); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceEnterpriseOrgImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("on_destroy", enterpriseOrgOnDestroyDetach); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceEnterpriseOrgUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+
+	if d.HasChange("name") {
+		body := map[string]string{"name": d.Get("name").(string)}
+		respBody, statusCode, err := devopsRequest(ctx, client, http.MethodPatch, "v2/enterprises/organizations/"+d.Id(), body)
+		if err != nil {
+			return diag.FromErr(err)
+		} else if statusCode >= 300 {
+			return diag.Errorf("error renaming Enterprise Organization %s: Status: %d, %s", d.Id(), statusCode, respBody)
+		}
 	}
+	return resourceEnterpriseOrgRead(ctx, d, meta)
 }
 
 func resourceEnterpriseOrgDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.Diagnostics{
-		diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  "Delete of Enterprise Organizations not supported",
-			Detail:   "Delete of Enterprise Organizations not supported.",
-		},
+	if d.Get("on_destroy").(string) != enterpriseOrgOnDestroyDelete {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Enterprise Organization detached",
+				Detail:   fmt.Sprintf("Enterprise Organization %s was removed from the Terraform state but still exists in the Enterprise. Set \"on_destroy\" to \"delete\" to delete it.", d.Id()),
+			},
+		}
 	}
+
+	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+	respBody, statusCode, err := devopsRequest(ctx, client, http.MethodDelete, "v2/enterprises/organizations/"+d.Id(), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	} else if statusCode == http.StatusNotFound {
+		return nil
+	} else if statusCode >= 300 {
+		return diag.Errorf("error deleting Enterprise Organization %s: Status: %d, %s", d.Id(), statusCode, respBody)
+	}
+	return nil
+}
+
+// getEnterpriseOrg returns the organization of the Enterprise with the given ID, or nil if it does not exist.
+func getEnterpriseOrg(ctx context.Context, client *astra.ClientWithResponses, orgID string) (*astra.CreateOrgInEnterpriseResponse, error) {
+	respBody, statusCode, err := devopsRequest(ctx, client, http.MethodGet, "v2/enterprises/organizations/"+orgID, nil)
+	if err != nil {
+		return nil, err
+	} else if statusCode == http.StatusNotFound {
+		return nil, nil
+	} else if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("error reading Enterprise Organization %s: Status: %d, %s", orgID, statusCode, respBody)
+	}
+	var org astra.CreateOrgInEnterpriseResponse
+	if err := json.Unmarshal(respBody, &org); err != nil {
+		return nil, fmt.Errorf("error decoding Enterprise Organization %s: %w", orgID, err)
+	}
+	return &org, nil
+}
+
+// listEnterpriseOrgs returns the organizations of the Enterprise of the current organization.
+func listEnterpriseOrgs(ctx context.Context, client *astra.ClientWithResponses) ([]astra.CreateOrgInEnterpriseResponse, error) {
+	respBody, statusCode, err := devopsRequest(ctx, client, http.MethodGet, "v2/enterprises/organizations", nil)
+	if err != nil {
+		return nil, err
+	} else if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("error listing Enterprise Organizations: Status: %d, %s", statusCode, respBody)
+	}
+	var orgs []astra.CreateOrgInEnterpriseResponse
+	if err := json.Unmarshal(respBody, &orgs); err != nil {
+		return nil, fmt.Errorf("error decoding Enterprise Organizations: %w", err)
+	}
+	return orgs, nil
 }
 
 func setEnterpriseOrgData(d *schema.ResourceData, org *astra.CreateOrgInEnterpriseResponse) error {
 	if org == nil {
 		return errors.New("organization is nil")
 	}
-	d.Set("enterprise_id", *org.EnterpriseId)
-	d.Set("organization_id", *org.OrganizationID)
-	d.Set("organization_type", *org.OrgType)
-	d.Set("organization_group_id", *org.OrganizationGroupId)
-	d.Set("created_at", *org.CreatedAt)
-	d.Set("last_modified", *org.LastModified)
-
+	flatOrg := flattenEnterpriseOrg(*org)
+	// the name and email are not always returned on creation
+	if org.OrganizationName == nil {
+		delete(flatOrg, "name")
+	}
+	if org.OrganizationEmail == nil {
+		delete(flatOrg, "email")
+	}
+	for k, v := range flatOrg {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func flattenEnterpriseOrg(org astra.CreateOrgInEnterpriseResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                  astra.StringValue(org.OrganizationName),
+		"email":                 astra.StringValue(org.OrganizationEmail),
+		"enterprise_id":         astra.StringValue(org.EnterpriseId),
+		"organization_id":       astra.StringValue(org.OrganizationID),
+		"organization_type":     astra.StringValue(org.OrgType),
+		"organization_group_id": astra.StringValue(org.OrganizationGroupId),
+		"created_at":            astra.StringValue(org.CreatedAt),
+		"last_modified":         astra.StringValue(org.LastModified),
+	}
+}
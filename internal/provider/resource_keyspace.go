@@ -2,60 +2,164 @@ package provider
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Mutex for synchronizing Keyspace creation
 var keyspaceMutex sync.Mutex
 
-func resourceKeyspace() *schema.Resource {
-	return &schema.Resource{
-		Description:   "`astra_keyspace` provides a keyspace resource. Keyspaces are groupings of tables for Cassandra. `astra_keyspace` resources are associated with a database id. You can have multiple keyspaces per DB in addition to the default keyspace provided in the `astra_database` resource.",
-		CreateContext: resourceKeyspaceCreate,
-		ReadContext:   resourceKeyspaceRead,
-		DeleteContext: resourceKeyspaceDelete,
+var (
+	_ resource.Resource                 = &keyspaceResource{}
+	_ resource.ResourceWithConfigure    = &keyspaceResource{}
+	_ resource.ResourceWithImportState  = &keyspaceResource{}
+	_ resource.ResourceWithIdentity     = &keyspaceResource{}
+	_ resource.ResourceWithUpgradeState = &keyspaceResource{}
+)
+
+func NewKeyspaceResource() resource.Resource {
+	return &keyspaceResource{}
+}
+
+type keyspaceResource struct {
+	clients *astraClients2
+}
+
+type keyspaceResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	DatabaseID         types.String   `tfsdk:"database_id"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	FailIfNotEmpty     types.Bool     `tfsdk:"fail_if_not_empty"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+type keyspaceResourceIdentityModel struct {
+	DatabaseID types.String `tfsdk:"database_id"`
+	Name       types.String `tfsdk:"name"`
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+func (r *keyspaceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keyspace"
+}
+
+func (r *keyspaceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`astra_keyspace` provides a keyspace resource. Keyspaces are groupings of tables for Cassandra. `astra_keyspace` resources are associated with a database id. You can have multiple keyspaces per DB in addition to the default keyspace provided in the `astra_database` resource.\n\n" +
+			"Keyspaces can be imported with an ID of the form `database_id/keyspace`, or with an `import` block using the `database_id` and `name` identity attributes (Terraform v1.12.0 and later).",
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the keyspace, in the format `database_id/keyspace`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Keyspace name can have up to 48 alpha-numeric characters and contain underscores; only letters and numbers are supported as the first character.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyspaceNameRegex, "invalid keyspace name"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database_id": schema.StringAttribute{
+				Description: "Astra database to create the keyspace.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegex, "must be a valid UUID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether or not to allow Terraform to destroy the keyspace. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the keyspace will fail. Defaults to `true`. The state of keyspaces created by provider versions which did not support this attribute is upgraded with `false`, so they stay unprotected until the next `terraform apply`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"fail_if_not_empty": schema.BoolAttribute{
+				Description: "Whether or not to refuse to destroy the keyspace while it still contains tables. When set, the error lists the remaining tables along with an estimate of their number of partitions. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
 		},
+	}
+}
 
-		Schema: map[string]*schema.Schema{
-			// Required
-			"name": {
-				Description:      "Keyspace name can have up to 48 alpha-numeric characters and contain underscores; only letters and numbers are supported as the first character.",
-				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validateKeyspace,
+func (r *keyspaceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"database_id": identityschema.StringAttribute{
+				Description:       "The ID of the Astra database.",
+				RequiredForImport: true,
 			},
-			"database_id": {
-				Description:  "Astra database to create the keyspace.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.IsUUID,
+			"name": identityschema.StringAttribute{
+				Description:       "The keyspace name.",
+				RequiredForImport: true,
 			},
 		},
 	}
 }
 
-func resourceKeyspaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+func (r *keyspaceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+func (r *keyspaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan keyspaceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	databaseID := d.Get("database_id").(string)
-	keyspaceName := d.Get("name").(string)
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.astraClient
+	databaseID := plan.DatabaseID.ValueString()
+	keyspaceName := plan.Name.ValueString()
 
 	//Wait for DB to be in Active status
-	if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+	if err := retry.RetryContext(ctx, createTimeout, func() *retry.RetryError {
 		keyspaceMutex.Lock()
 		res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
 		keyspaceMutex.Unlock()
@@ -95,59 +199,91 @@ func resourceKeyspaceCreate(ctx context.Context, d *schema.ResourceData, meta in
 			} else if resp.StatusCode() >= 400 {
 				return retry.NonRetryableError(fmt.Errorf("error adding keyspace to database (not retrying): %s", string(resp.Body)))
 			}
-
-			if err := setKeyspaceResourceData(d, databaseID, keyspaceName); err != nil {
-				return retry.NonRetryableError(fmt.Errorf("error setting keyspace data (not retrying) %s", err))
-			}
-
 			return nil
 		default:
 			return retry.RetryableError(fmt.Errorf("expected database to be active but is %s", db.Status))
 		}
 	}); err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error creating keyspace", err.Error())
+		return
 	}
 
-	return nil
+	plan.ID = types.StringValue(keyspaceID(databaseID, keyspaceName))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 }
 
-func resourceKeyspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
-
-	id := d.Id()
-	databaseID, keyspaceName, err := parseKeyspaceID(id)
-	if err != nil {
-		return diag.FromErr(err)
+func (r *keyspaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state keyspaceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	keyspaces, err := listKeyspaces(ctx, client, databaseID)
+	databaseID := state.DatabaseID.ValueString()
+	keyspaceName := state.Name.ValueString()
+
+	keyspaces, err := listKeyspaces(ctx, r.clients.astraClient, databaseID)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error reading keyspace", err.Error())
+		return
 	}
 
 	for _, k := range keyspaces {
 		if k == keyspaceName {
-			if err := setKeyspaceResourceData(d, databaseID, keyspaceName); err != nil {
-				return diag.FromErr(err)
-			}
-			return nil
+			state.ID = types.StringValue(keyspaceID(databaseID, keyspaceName))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			resp.Diagnostics.Append(resp.Identity.Set(ctx, state.identity())...)
+			return
 		}
 	}
 
 	// Keyspace not found. Remove from state.
-	d.SetId("")
+	resp.State.RemoveResource(ctx)
+}
+
+// Update only handles the attributes which are not sent to Astra, all the other attributes force a new keyspace.
+func (r *keyspaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan keyspaceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 }
 
-func resourceKeyspaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
+func (r *keyspaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state keyspaceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Error deleting keyspace", "\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_keyspace")
+		return
+	}
 
-	databaseID := d.Get("database_id").(string)
-	keyspaceName := d.Get("name").(string)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clients.astraClient
+	databaseID := state.DatabaseID.ValueString()
+	keyspaceName := state.Name.ValueString()
+
+	if state.FailIfNotEmpty.ValueBool() {
+		if err := checkKeyspaceEmpty(ctx, r.clients.restClientPool, r.clients.token, databaseID, keyspaceName); err != nil {
+			resp.Diagnostics.AddError("Error deleting keyspace", err.Error())
+			return
+		}
+	}
 
 	//Wait for DB to be in Active status
-	if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+	if err := retry.RetryContext(ctx, deleteTimeout, func() *retry.RetryError {
 		keyspaceMutex.Lock()
 		res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
 		keyspaceMutex.Unlock()
@@ -183,39 +319,200 @@ func resourceKeyspaceDelete(ctx context.Context, d *schema.ResourceData, meta in
 				return retry.RetryableError(fmt.Errorf("error dropping keyspace from database (retrying): %s", string(resp.Body)))
 			} else if resp.StatusCode() == 401 {
 				// DevOps API returns 401 Unauthorized for requests without the keyspace drop permission
-				return retry.NonRetryableError(fmt.Errorf("error adding keyspace to database (insufficient permissions, role missing 'db-keyspace-drop')"))
+				return retry.NonRetryableError(fmt.Errorf("error dropping keyspace from database (insufficient permissions, role missing 'db-keyspace-drop')"))
 			} else if resp.StatusCode() >= 400 {
 				return retry.NonRetryableError(fmt.Errorf("error dropping keyspace from database (not retrying): %s", string(resp.Body)))
 			}
-
-			d.SetId("")
 			return nil
 		default:
 			return retry.RetryableError(fmt.Errorf("expected database to be active but is %s", db.Status))
 		}
 	}); err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error deleting keyspace", err.Error())
 	}
-	d.SetId("")
-	return nil
 }
 
-func setKeyspaceResourceData(d *schema.ResourceData, databaseID string, keyspaceName string) error {
-	d.SetId(fmt.Sprintf("%s/keyspace/%s", databaseID, keyspaceName))
-	if err := d.Set("name", keyspaceName); err != nil {
-		return err
+// ImportState accepts either an ID of the form database_id/keyspace, or the resource identity.
+func (r *keyspaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var databaseID, keyspaceName string
+	if req.ID != "" {
+		var err error
+		databaseID, keyspaceName, err = parseKeyspaceID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing keyspace", err.Error())
+			return
+		}
+	} else {
+		var identity keyspaceResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		databaseID = identity.DatabaseID.ValueString()
+		keyspaceName = identity.Name.ValueString()
 	}
-	if err := d.Set("database_id", databaseID); err != nil {
-		return err
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), keyspaceID(databaseID, keyspaceName))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_id"), databaseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), keyspaceName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fail_if_not_empty"), false)...)
+}
+
+// UpgradeState migrates the state of the SDK implementation of astra_keyspace, which used IDs of the form database_id/keyspace/name.
+func (r *keyspaceResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                  schema.StringAttribute{Computed: true},
+					"name":                schema.StringAttribute{Required: true},
+					"database_id":         schema.StringAttribute{Required: true},
+					"deletion_protection": schema.BoolAttribute{Optional: true},
+					"fail_if_not_empty":   schema.BoolAttribute{Optional: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					ID                 types.String `tfsdk:"id"`
+					Name               types.String `tfsdk:"name"`
+					DatabaseID         types.String `tfsdk:"database_id"`
+					DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+					FailIfNotEmpty     types.Bool   `tfsdk:"fail_if_not_empty"`
+				}
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// deletion_protection did not exist in older versions, do not enable it on existing resources
+				upgraded := keyspaceResourceModel{
+					ID:                 types.StringValue(keyspaceID(prior.DatabaseID.ValueString(), prior.Name.ValueString())),
+					Name:               prior.Name,
+					DatabaseID:         prior.DatabaseID,
+					DeletionProtection: ElvisTF(&prior.DeletionProtection, types.BoolValue(false)),
+					FailIfNotEmpty:     ElvisTF(&prior.FailIfNotEmpty, types.BoolValue(false)),
+					Timeouts:           nullTimeouts(map[string]attr.Type{"create": types.StringType, "delete": types.StringType}),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
 	}
+}
 
-	return nil
+func (m keyspaceResourceModel) identity() keyspaceResourceIdentityModel {
+	return keyspaceResourceIdentityModel{
+		DatabaseID: m.DatabaseID,
+		Name:       m.Name,
+	}
 }
 
+func keyspaceID(databaseID, keyspaceName string) string {
+	return fmt.Sprintf("%s/%s", databaseID, keyspaceName)
+}
+
+// parseKeyspaceID returns the database ID and keyspace name from an ID of the form database_id/keyspace.  The legacy format
+// database_id/keyspace/keyspace_name used by earlier versions of the provider is also accepted.
 func parseKeyspaceID(id string) (string, string, error) {
-	idParts := strings.Split(id, "/keyspace/")
-	if len(idParts) != 2 {
-		return "", "", errors.New("invalid keyspace id format: expected database_id/keyspace")
+	idParts := strings.Split(id, "/")
+	if len(idParts) == 3 && idParts[1] == "keyspace" {
+		idParts = []string{idParts[0], idParts[2]}
+	}
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("invalid keyspace id format %q: expected database_id/keyspace", id)
 	}
 	return idParts[0], idParts[1], nil
 }
+
+// nullTimeouts returns a null timeouts block with the given attributes, for use when building state from scratch.
+func nullTimeouts(attrTypes map[string]attr.Type) timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(attrTypes),
+	}
+}
+
+// checkKeyspaceEmpty returns an error listing the tables of the keyspace if it contains any.
+func checkKeyspaceEmpty(ctx context.Context, restClients *restClientPool, token, databaseID, keyspaceName string) error {
+	restClient, _, err := restClients.get(ctx, databaseID, "")
+	if err != nil {
+		return err
+	}
+	params := astrarestapi.GetTablesParams{
+		XCassandraToken: token,
+	}
+	tablesResp, err := restClient.GetTablesWithResponse(ctx, keyspaceName, &params)
+	if err != nil {
+		restClients.evict(databaseID)
+		return fmt.Errorf("error listing tables in keyspace %s: %w", keyspaceName, err)
+	} else if tablesResp.StatusCode() == 401 || tablesResp.StatusCode() >= 500 {
+		restClients.evict(databaseID)
+		return fmt.Errorf("error listing tables in keyspace %s, status code: %d, message: %s", keyspaceName, tablesResp.StatusCode(), string(tablesResp.Body))
+	} else if tablesResp.StatusCode() >= 400 || tablesResp.JSON200 == nil {
+		return fmt.Errorf("error listing tables in keyspace %s, status code: %d, message: %s", keyspaceName, tablesResp.StatusCode(), string(tablesResp.Body))
+	}
+	if tablesResp.JSON200.Data == nil || len(*tablesResp.JSON200.Data) == 0 {
+		return nil
+	}
+
+	tableNames := make([]string, 0, len(*tablesResp.JSON200.Data))
+	for _, t := range *tablesResp.JSON200.Data {
+		tableNames = append(tableNames, t.Name)
+	}
+	estimates, err := estimateTablePartitions(ctx, restClient, token, keyspaceName)
+	if err != nil {
+		// The estimates are only informational, so don't fail on them
+		tflog.Warn(ctx, fmt.Sprintf("unable to estimate the size of the tables in keyspace %s: %v", keyspaceName, err))
+	}
+	return nonEmptyKeyspaceError(keyspaceName, tableNames, estimates)
+}
+
+func nonEmptyKeyspaceError(keyspaceName string, tableNames []string, estimates map[string]int64) error {
+	sort.Strings(tableNames)
+	descriptions := make([]string, 0, len(tableNames))
+	for _, name := range tableNames {
+		if estimate, ok := estimates[name]; ok {
+			descriptions = append(descriptions, fmt.Sprintf("%s (~%d partitions)", name, estimate))
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%s (size unknown)", name))
+		}
+	}
+	return fmt.Errorf("keyspace %s is not empty and \"fail_if_not_empty\" is set, it still contains %d table(s): %s",
+		keyspaceName, len(tableNames), strings.Join(descriptions, ", "))
+}
+
+// estimateTablePartitions returns the estimated number of partitions of each table of a keyspace, based on the
+// system.size_estimates table. The estimates are refreshed periodically by Cassandra and are not exact.
+func estimateTablePartitions(ctx context.Context, restClient *astrarestapi.ClientWithResponses, token, keyspaceName string) (map[string]int64, error) {
+	where, err := json.Marshal(map[string]interface{}{
+		"keyspace_name": map[string]string{"$eq": keyspaceName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{
+		"where":     {string(where)},
+		"fields":    {"table_name,partitions_count"},
+		"page-size": {"10000"},
+	}
+	body, statusCode, err := restGet(ctx, restClient, token, "v2/keyspaces/system/size_estimates", query)
+	if err != nil {
+		return nil, err
+	} else if statusCode >= 400 {
+		return nil, fmt.Errorf("status code: %d, message: %s", statusCode, string(body))
+	}
+
+	var rows struct {
+		Data []struct {
+			TableName       string `json:"table_name"`
+			PartitionsCount int64  `json:"partitions_count"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, err
+	}
+	estimates := map[string]int64{}
+	for _, r := range rows.Data {
+		estimates[r.TableName] += r.PartitionsCount
+	}
+	return estimates, nil
+}
@@ -1,11 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestKeyspace(t *testing.T) {
@@ -13,12 +22,18 @@ func TestKeyspace(t *testing.T) {
 	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccKeyspaceConfiguration(databaseID),
 			},
+			{
+				ResourceName:            "astra_keyspace.keyspace-1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "timeouts"},
+			},
 		},
 	})
 }
@@ -27,22 +42,122 @@ func TestKeyspace(t *testing.T) {
 func testAccKeyspaceConfiguration(databaseID string) string {
 	return fmt.Sprintf(`
 resource "astra_keyspace" "keyspace-1" {
-  name        = "ks1"
-  database_id = "%s"
-
+  name                = "ks1"
+  database_id         = "%s"
+  deletion_protection = false
 }
 
 resource "astra_keyspace" "keyspace-2" {
-  name        = "ks2"
-  database_id = "%s"
-
+  name                = "ks2"
+  database_id         = "%s"
+  deletion_protection = false
 }
 
 resource "astra_keyspace" "keyspace-3" {
-  name        = "ks3"
-  database_id = "%s"
-
+  name                = "ks3"
+  database_id         = "%s"
+  deletion_protection = false
 }
 
 `, databaseID, databaseID, databaseID)
 }
+
+func TestNonEmptyKeyspaceError(t *testing.T) {
+	err := nonEmptyKeyspaceError("ks1", []string{"orders", "customers"}, map[string]int64{"orders": 1200})
+	assert.EqualError(t, err, `keyspace ks1 is not empty and "fail_if_not_empty" is set, it still contains 2 table(s): customers (size unknown), orders (~1200 partitions)`)
+
+	err = nonEmptyKeyspaceError("ks1", []string{"orders"}, nil)
+	assert.EqualError(t, err, `keyspace ks1 is not empty and "fail_if_not_empty" is set, it still contains 1 table(s): orders (size unknown)`)
+}
+
+func TestParseKeyspaceID(t *testing.T) {
+	databaseID, keyspace, err := parseKeyspaceID("48bfc13b-c1a5-48db-b70f-b6ef9709872b/ks1")
+	assert.NoError(t, err)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b", databaseID)
+	assert.Equal(t, "ks1", keyspace)
+
+	// legacy format
+	databaseID, keyspace, err = parseKeyspaceID("48bfc13b-c1a5-48db-b70f-b6ef9709872b/keyspace/ks1")
+	assert.NoError(t, err)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b", databaseID)
+	assert.Equal(t, "ks1", keyspace)
+
+	_, _, err = parseKeyspaceID("48bfc13b-c1a5-48db-b70f-b6ef9709872b/ks1/tbl1")
+	assert.Error(t, err)
+	_, _, err = parseKeyspaceID("48bfc13b-c1a5-48db-b70f-b6ef9709872b")
+	assert.Error(t, err)
+}
+
+func TestCheckKeyspaceEmptyEvictsClient(t *testing.T) {
+	databaseID := "48bfc13b-c1a5-48db-b70f-b6ef9709872b"
+	statusCode := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	restClient, err := astrarestapi.NewClientWithResponses(server.URL)
+	assert.NoError(t, err)
+	restClients := newRestClientPool(nil, "test", "test")
+	seed := func() {
+		restClients.clients[restClientPoolKey(databaseID, "")] = restClientPoolEntry{client: restClient, region: "us-east1", resolvedAt: time.Now()}
+	}
+
+	// an unavailable datacenter evicts the cached client
+	seed()
+	assert.Error(t, checkKeyspaceEmpty(context.Background(), restClients, "token", databaseID, "ks1"))
+	assert.Empty(t, restClients.clients)
+
+	// other client errors keep the cached client
+	seed()
+	statusCode = http.StatusNotFound
+	assert.Error(t, checkKeyspaceEmpty(context.Background(), restClients, "token", databaseID, "ks1"))
+	assert.Len(t, restClients.clients, 1)
+
+	statusCode = http.StatusOK
+	assert.NoError(t, checkKeyspaceEmpty(context.Background(), restClients, "token", databaseID, "ks1"))
+	assert.Len(t, restClients.clients, 1)
+}
+
+func TestKeyspaceResourceUpgradeStateFromSDK(t *testing.T) {
+	ctx := context.Background()
+	r := &keyspaceResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	upgrade := func(deletionProtection interface{}) keyspaceResourceModel {
+		priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+		priorState := tftypes.NewValue(priorType, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, "48bfc13b-c1a5-48db-b70f-b6ef9709872b/keyspace/ks1"),
+			"name":                tftypes.NewValue(tftypes.String, "ks1"),
+			"database_id":         tftypes.NewValue(tftypes.String, "48bfc13b-c1a5-48db-b70f-b6ef9709872b"),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, deletionProtection),
+			"fail_if_not_empty":   tftypes.NewValue(tftypes.Bool, nil),
+		})
+		req := fwresource.UpgradeStateRequest{
+			State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorState},
+		}
+		resp := fwresource.UpgradeStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}
+		upgrader.StateUpgrader(ctx, req, &resp)
+		assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var upgraded keyspaceResourceModel
+		assert.False(t, resp.State.Get(ctx, &upgraded).HasError())
+		return upgraded
+	}
+
+	// states created before deletion_protection existed keep allowing the keyspace to be destroyed
+	upgraded := upgrade(nil)
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b/ks1", upgraded.ID.ValueString())
+	assert.False(t, upgraded.DeletionProtection.ValueBool())
+	assert.False(t, upgraded.FailIfNotEmpty.ValueBool())
+
+	upgraded = upgrade(true)
+	assert.True(t, upgraded.DeletionProtection.ValueBool())
+}
@@ -0,0 +1,839 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                     = &StreamingFunctionResource{}
+	_ resource.ResourceWithConfigure        = &StreamingFunctionResource{}
+	_ resource.ResourceWithConfigValidators = &StreamingFunctionResource{}
+	_ resource.ResourceWithModifyPlan       = &StreamingFunctionResource{}
+	_ resource.ResourceWithImportState      = &StreamingFunctionResource{}
+)
+
+// errFunctionPackageChanged is returned when the function package changed between the plan and the apply.
+var errFunctionPackageChanged = errors.New("function package changed since the plan was created")
+
+func NewStreamingFunctionResource() resource.Resource {
+	return &StreamingFunctionResource{}
+}
+
+// StreamingFunctionResource is a Pulsar Function, which processes the messages of its input topics and can publish the
+// results to an output topic.
+type StreamingFunctionResource struct {
+	clients *astraClients2
+}
+
+type StreamingFunctionResourceModel struct {
+	ID                   types.String                     `tfsdk:"id"`
+	Cluster              types.String                     `tfsdk:"cluster"`
+	TenantName           types.String                     `tfsdk:"tenant_name"`
+	Namespace            types.String                     `tfsdk:"namespace"`
+	FunctionName         types.String                     `tfsdk:"function_name"`
+	Archive              types.String                     `tfsdk:"archive"`
+	PackagePath          types.String                     `tfsdk:"package_path"`
+	PackageHash          types.String                     `tfsdk:"package_hash"`
+	Runtime              types.String                     `tfsdk:"runtime"`
+	ClassName            types.String                     `tfsdk:"class_name"`
+	Inputs               []string                         `tfsdk:"inputs"`
+	TopicsPattern        types.String                     `tfsdk:"topics_pattern"`
+	InputSpecs           map[string]pulsarConsumerSpec    `tfsdk:"input_specs"`
+	Output               types.String                     `tfsdk:"output"`
+	OutputSchemaType     types.String                     `tfsdk:"output_schema_type"`
+	LogTopic             types.String                     `tfsdk:"log_topic"`
+	UserConfig           types.String                     `tfsdk:"user_config"`
+	Secrets              map[string]pulsarConnectorSecret `tfsdk:"secrets"`
+	SubscriptionName     types.String                     `tfsdk:"subscription_name"`
+	SubscriptionPosition types.String                     `tfsdk:"subscription_position"`
+	ProcessingGuarantees types.String                     `tfsdk:"processing_guarantees"`
+	RetainOrdering       types.Bool                       `tfsdk:"retain_ordering"`
+	AutoAck              types.Bool                       `tfsdk:"auto_ack"`
+	MaxMessageRetries    types.Int32                      `tfsdk:"max_message_retries"`
+	DeadLetterTopic      types.String                     `tfsdk:"dead_letter_topic"`
+	Parallelism          types.Int32                      `tfsdk:"parallelism"`
+	Resources            *pulsarConnectorResources        `tfsdk:"resources"`
+	DeletionProtection   types.Bool                       `tfsdk:"deletion_protection"`
+	WaitForRunning       types.Bool                       `tfsdk:"wait_for_running"`
+	Timeouts             timeouts.Value                   `tfsdk:"timeouts"`
+	pulsarConnectorStatusModel
+}
+
+// streamingFunctionConfig is the configuration of a Pulsar function, as sent to and returned by the functions worker.
+type streamingFunctionConfig struct {
+	Tenant               string                                    `json:"tenant"`
+	Namespace            string                                    `json:"namespace"`
+	Name                 string                                    `json:"name"`
+	ClassName            *string                                   `json:"className,omitempty"`
+	Runtime              *string                                   `json:"runtime,omitempty"`
+	Jar                  *string                                   `json:"jar,omitempty"`
+	Inputs               []string                                  `json:"inputs,omitempty"`
+	TopicsPattern        *string                                   `json:"topicsPattern,omitempty"`
+	InputSpecs           *map[string]astrastreaming.ConsumerConfig `json:"inputSpecs,omitempty"`
+	Output               *string                                   `json:"output,omitempty"`
+	OutputSchemaType     *string                                   `json:"outputSchemaType,omitempty"`
+	LogTopic             *string                                   `json:"logTopic,omitempty"`
+	UserConfig           map[string]interface{}                    `json:"userConfig,omitempty"`
+	Secrets              *map[string]map[string]interface{}        `json:"secrets,omitempty"`
+	SubName              *string                                   `json:"subName,omitempty"`
+	SubscriptionPosition *string                                   `json:"subscriptionPosition,omitempty"`
+	ProcessingGuarantees *string                                   `json:"processingGuarantees,omitempty"`
+	RetainOrdering       *bool                                     `json:"retainOrdering,omitempty"`
+	AutoAck              *bool                                     `json:"autoAck,omitempty"`
+	MaxMessageRetries    *int32                                    `json:"maxMessageRetries,omitempty"`
+	DeadLetterTopic      *string                                   `json:"deadLetterTopic,omitempty"`
+	Parallelism          *int32                                    `json:"parallelism,omitempty"`
+	Resources            *astrastreaming.Resources                 `json:"resources,omitempty"`
+}
+
+func (r *StreamingFunctionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_streaming_function"
+}
+
+func (r *StreamingFunctionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Deploys a Pulsar Function, or a transform function, which processes the messages of its input topics.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique ID in the form cluster_name/tenant_name/namespace/function_name",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				Description: "Name of the pulsar cluster in which to create the function.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 32),
+				},
+			},
+			"tenant_name": schema.StringAttribute{
+				Description: "Streaming tenant name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 64),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description: "Pulsar Namespace",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 64),
+				},
+			},
+			"function_name": schema.StringAttribute{
+				Description: "Name of the function. Note that the combination of tenant, namespace, and function name must not exceed 47 characters.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"archive": schema.StringAttribute{
+				Description: "URL of the function package, e.g. 'builtin://transforms' for the transform functions of Astra Streaming, " +
+					"or a 'function://' or 'https://' URL. Exactly one of `archive` or `package_path` must be set. Changing the archive redeploys the function in place.",
+				Optional: true,
+			},
+			"package_path": schema.StringAttribute{
+				Description: "Path of a local JAR, NAR, Python or Go package which is uploaded as the function package. " +
+					"Exactly one of `archive` or `package_path` must be set. Changing the path or the content of the file redeploys the function in place.",
+				Optional: true,
+			},
+			"package_hash": schema.StringAttribute{
+				Description: "SHA-256 hash of the content of the file at `package_path`, used to redeploy the function when the file changes.",
+				Computed:    true,
+			},
+			"runtime": schema.StringAttribute{
+				Description: "Runtime of the function, \"JAVA\", \"PYTHON\" or \"GO\". Defaults to \"JAVA\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("JAVA"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("JAVA", "PYTHON", "GO"),
+				},
+			},
+			"class_name": schema.StringAttribute{
+				Description: "Class name of the function, e.g. 'com.datastax.oss.pulsar.functions.transforms.TransformFunction'. " +
+					"Changing the class name updates the function in place.",
+				Optional: true,
+			},
+			"inputs": schema.ListAttribute{
+				Description: "Input topics of the function. At least one of `inputs`, `topics_pattern` or `input_specs` must be set.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"topics_pattern": schema.StringAttribute{
+				Description: "Regular expression matching the input topics of the function, e.g. 'persistent://my-tenant/my-namespace/orders-.*'.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"input_specs": pulsarConsumerSpecsSchema(mapplanmodifier.RequiresReplace()),
+			"output": schema.StringAttribute{
+				Description: "Full name of the topic which the results of the function are published to. If not set, the results are discarded.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"output_schema_type": schema.StringAttribute{
+				Description: "Schema type of the output topic, for example \"AVRO\", \"JSON\" or \"STRING\".",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"log_topic": schema.StringAttribute{
+				Description: "Full name of the topic which the logs of the function are published to. Changing the log topic updates the function in place.",
+				Optional:    true,
+			},
+			"user_config": schema.StringAttribute{
+				Description: "User configuration of the function, as a JSON string. Changing the configuration updates the function in place, formatting and key order are ignored.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					planModifierSuppressJSONDiff(),
+				},
+			},
+			"secrets": pulsarConnectorSecretsSchema(),
+			"subscription_name": schema.StringAttribute{
+				Description: "Name of the subscription used to consume the input topics. Defaults to a name derived from the function.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subscription_position": schema.StringAttribute{
+				Description: "Initial position of the subscription, \"Latest\" or \"Earliest\". Defaults to \"Latest\".",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("Latest", "Earliest"),
+				},
+			},
+			"processing_guarantees": schema.StringAttribute{
+				Description: "\"ATLEAST_ONCE\" \"ATMOST_ONCE\" \"EFFECTIVELY_ONCE\". Defaults to \"ATLEAST_ONCE\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("ATLEAST_ONCE"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("ATLEAST_ONCE", "ATMOST_ONCE", "EFFECTIVELY_ONCE"),
+				},
+			},
+			"retain_ordering": schema.BoolAttribute{
+				Description: "Whether the messages are processed in the order they are received. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"auto_ack": schema.BoolAttribute{
+				Description: "Whether the messages are acknowledged automatically once processed. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_message_retries": schema.Int32Attribute{
+				Description: "Number of times a message is redelivered before it is dropped, or sent to the `dead_letter_topic`.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"dead_letter_topic": schema.StringAttribute{
+				Description: "Topic which the messages are sent to after `max_message_retries` failed attempts.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("max_message_retries")),
+				},
+			},
+			"parallelism": schema.Int32Attribute{
+				Description: "Parallelism for Pulsar function. Changing the parallelism updates the function in place.",
+				Required:    true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"resources": pulsarConnectorResourcesSchema(),
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether or not to allow Terraform to destroy this streaming function. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the instance will fail. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"wait_for_running": schema.BoolAttribute{
+				Description: "Whether to wait for all the instances of the function to be running after it is created or updated. " +
+					"The create or update fails if the instances are not running before the timeout. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+	for name, attribute := range pulsarConnectorStatusAttributes("function") {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (r *StreamingFunctionResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("archive"),
+			path.MatchRoot("package_path"),
+		),
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("inputs"),
+			path.MatchRoot("topics_pattern"),
+			path.MatchRoot("input_specs"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("topics_pattern"),
+			path.MatchRoot("inputs"),
+		),
+	}
+}
+
+func (r *StreamingFunctionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+// ModifyPlan sets the hash of the local package, so that the function is redeployed when the content of the file changes.
+func (r *StreamingFunctionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// resource is being destroyed
+		return
+	}
+
+	var packagePath types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("package_path"), &packagePath)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	packageHash := types.StringNull()
+	if packagePath.IsUnknown() {
+		packageHash = types.StringUnknown()
+	} else if !packagePath.IsNull() {
+		hash, err := functionPackageHash(packagePath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("package_path"),
+				"failed to read function package",
+				err.Error())
+			return
+		}
+		packageHash = types.StringValue(hash)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("package_hash"), packageHash)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var statePackageHash types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("package_hash"), &statePackageHash)...)
+	if resp.Diagnostics.HasError() || packageHash.Equal(statePackageHash) {
+		return
+	}
+	// the package changed, the function is redeployed and its status is only known after the update
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("num_instances"), types.Int32Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("num_running"), types.Int32Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("num_restarts"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("latest_error"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("instances"), types.ListUnknown(types.ObjectType{AttrTypes: pulsarInstanceStatusAttrTypes}))...)
+}
+
+func (r *StreamingFunctionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := &StreamingFunctionResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultConnectorRunningTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contentType, body, err := plan.functionFormBody(true)
+	if errors.Is(err, errFunctionPackageChanged) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("package_path"),
+			"function package changed",
+			err.Error())
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"invalid function config",
+			err.Error())
+		return
+	}
+
+	respBody, statusCode, err := pulsarAdminRequest(ctx, r.clients.pulsarAdminClient, plan.Cluster.ValueString(), http.MethodPost,
+		functionPath(plan.TenantName.ValueString(), plan.Namespace.ValueString(), plan.FunctionName.ValueString()), contentType, body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to create function",
+			err.Error())
+		return
+	} else if statusCode > 299 {
+		resp.Diagnostics.AddError(
+			"failed to create function",
+			fmt.Sprintf("failed to create function, status code: %d, message: %s", statusCode, string(respBody)))
+		return
+	}
+
+	plan.setID()
+	// the function is saved even if it is not running, so that it is tainted rather than lost
+	err = r.refreshFunctionStatus(ctx, plan, plan.WaitForRunning.ValueBool(), nil, createTimeout, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"function is not running after create",
+			err.Error())
+	}
+}
+
+func (r *StreamingFunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := &StreamingFunctionResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := state.Cluster.ValueString()
+	tenantName := state.TenantName.ValueString()
+	namespace := state.Namespace.ValueString()
+	functionName := state.FunctionName.ValueString()
+
+	body, statusCode, err := pulsarAdminRequest(ctx, r.clients.pulsarAdminClient, cluster, http.MethodGet,
+		functionPath(tenantName, namespace, functionName), "", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to get function",
+			err.Error())
+		return
+	} else if statusCode == http.StatusNotFound {
+		// function not found, remove it from the state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if statusCode > 299 {
+		resp.Diagnostics.AddError(
+			"failed to get function",
+			fmt.Sprintf("failed to get function, status code: %d, message: %s", statusCode, string(body)))
+		return
+	}
+
+	var function streamingFunctionConfig
+	if err := json.Unmarshal(body, &function); err != nil {
+		resp.Diagnostics.AddError(
+			"failed to unmarshal function response",
+			err.Error())
+		return
+	}
+	setStreamingFunctionData(function, state)
+
+	status, err := getPulsarConnectorStatus(ctx, r.clients.astraStreamingClient, cluster, "functions", tenantName, namespace, functionName)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("failed to get function status: %v", err))
+	} else {
+		resp.Diagnostics.Append(state.setStatus(ctx, status)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the function in place when its configuration changed, uploading the package again if it changed, and
+// waits for its instances to be running again.
+func (r *StreamingFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := &StreamingFunctionResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	state := &StreamingFunctionResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultConnectorRunningTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated := plan.requiresFunctionUpdate(state)
+	var previousStatus *pulsarConnectorStatus
+	if updated {
+		if plan.WaitForRunning.ValueBool() {
+			// the instances of the previous deployment are still reported as running right after the update
+			previousStatus, _ = getPulsarConnectorStatus(ctx, r.clients.astraStreamingClient, state.Cluster.ValueString(), "functions",
+				state.TenantName.ValueString(), state.Namespace.ValueString(), state.FunctionName.ValueString())
+		}
+
+		contentType, body, err := plan.functionFormBody(plan.requiresPackageUpdate(state))
+		if errors.Is(err, errFunctionPackageChanged) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("package_path"),
+				"function package changed",
+				err.Error())
+			return
+		} else if err != nil {
+			resp.Diagnostics.AddError(
+				"invalid function config",
+				err.Error())
+			return
+		}
+
+		respBody, statusCode, err := pulsarAdminRequest(ctx, r.clients.pulsarAdminClient, state.Cluster.ValueString(), http.MethodPut,
+			functionPath(state.TenantName.ValueString(), state.Namespace.ValueString(), state.FunctionName.ValueString()), contentType, body)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"failed to update function",
+				err.Error())
+			return
+		} else if statusCode > 299 {
+			resp.Diagnostics.AddError(
+				"failed to update function",
+				fmt.Sprintf("failed to update function, status code: %d, message: %s", statusCode, string(respBody)))
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	err := r.refreshFunctionStatus(ctx, plan, updated && plan.WaitForRunning.ValueBool(), previousStatus, updateTimeout, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"function is not running after update",
+			err.Error())
+	}
+}
+
+func (r *StreamingFunctionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := &StreamingFunctionResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"failed to delete function",
+			"deletion protection is enabled. Set `deletion_protection` to `false` to allow deletion of the function resource.")
+		return
+	}
+
+	body, statusCode, err := pulsarAdminRequest(ctx, r.clients.pulsarAdminClient, state.Cluster.ValueString(), http.MethodDelete,
+		functionPath(state.TenantName.ValueString(), state.Namespace.ValueString(), state.FunctionName.ValueString()), "", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to delete function",
+			err.Error())
+		return
+	} else if statusCode > 299 && statusCode != http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"failed to delete function",
+			fmt.Sprintf("failed to delete function, status code: %d, message: %s", statusCode, string(body)))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState reads the ID from the CLI and then calls Read() to get the state of the function. The package of the
+// function can't be read back, except for built-in archives, so `archive` or `package_path` is set by the next apply.
+func (r *StreamingFunctionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	functionID := strings.Split(req.ID, "/")
+	if len(functionID) != 4 {
+		resp.Diagnostics.AddError(
+			"Error importing function",
+			"ID must be in the format <cluster>/<tenant>/<namespace>/<function_name>",
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), functionID[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_name"), functionID[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), functionID[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("function_name"), functionID[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_running"), true)...)
+}
+
+// refreshFunctionStatus sets the status attributes of the model from the current status of the function, see refreshPulsarConnectorStatus.
+func (r *StreamingFunctionResource) refreshFunctionStatus(ctx context.Context, m *StreamingFunctionResourceModel, wait bool, previous *pulsarConnectorStatus, timeout time.Duration, diags *diag.Diagnostics) error {
+	status, err := refreshPulsarConnectorStatus(ctx, r.clients.astraStreamingClient, m.Cluster.ValueString(), "functions",
+		m.TenantName.ValueString(), m.Namespace.ValueString(), m.FunctionName.ValueString(), wait, int(m.Parallelism.ValueInt32()), previous, timeout)
+	diags.Append(m.setStatus(ctx, status)...)
+	return err
+}
+
+// functionPath returns the path of a function in the Pulsar admin API.
+func functionPath(tenant, namespace, name string) string {
+	return fmt.Sprintf("v3/functions/%s/%s/%s", tenant, namespace, name)
+}
+
+// functionPackageHash returns the hex encoded SHA-256 hash of the content of the function package at the given path.
+func functionPackageHash(packagePath string) (string, error) {
+	content, err := os.ReadFile(packagePath)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(content)), nil
+}
+
+// functionConfig returns the Pulsar configuration of the function.
+func (m *StreamingFunctionResourceModel) functionConfig() (streamingFunctionConfig, error) {
+	var userConfig map[string]interface{}
+	if m.UserConfig.ValueString() != "" {
+		if err := json.Unmarshal([]byte(m.UserConfig.ValueString()), &userConfig); err != nil {
+			return streamingFunctionConfig{}, fmt.Errorf("invalid user_config: %w", err)
+		}
+	}
+	config := streamingFunctionConfig{
+		Tenant:               m.TenantName.ValueString(),
+		Namespace:            m.Namespace.ValueString(),
+		Name:                 m.FunctionName.ValueString(),
+		ClassName:            m.ClassName.ValueStringPointer(),
+		Runtime:              m.Runtime.ValueStringPointer(),
+		Inputs:               m.Inputs,
+		TopicsPattern:        m.TopicsPattern.ValueStringPointer(),
+		InputSpecs:           pulsarConsumerConfigs(m.InputSpecs),
+		Output:               m.Output.ValueStringPointer(),
+		OutputSchemaType:     m.OutputSchemaType.ValueStringPointer(),
+		LogTopic:             m.LogTopic.ValueStringPointer(),
+		UserConfig:           userConfig,
+		Secrets:              pulsarConnectorSecrets(m.Secrets),
+		SubName:              m.SubscriptionName.ValueStringPointer(),
+		SubscriptionPosition: m.SubscriptionPosition.ValueStringPointer(),
+		ProcessingGuarantees: m.ProcessingGuarantees.ValueStringPointer(),
+		RetainOrdering:       m.RetainOrdering.ValueBoolPointer(),
+		AutoAck:              m.AutoAck.ValueBoolPointer(),
+		MaxMessageRetries:    m.MaxMessageRetries.ValueInt32Pointer(),
+		DeadLetterTopic:      m.DeadLetterTopic.ValueStringPointer(),
+		Parallelism:          m.Parallelism.ValueInt32Pointer(),
+		Resources:            m.Resources.apiResources(),
+	}
+	if strings.HasPrefix(m.Archive.ValueString(), builtinArchivePrefix) {
+		// built-in packages are referenced by the configuration rather than uploaded
+		config.Jar = m.Archive.ValueStringPointer()
+	}
+	return config, nil
+}
+
+// functionFormBody returns the multipart form sent to create or update the function, and its content type. If
+// withPackage is true, the package is uploaded, or referenced by URL, along with the configuration.
+func (m *StreamingFunctionResourceModel) functionFormBody(withPackage bool) (string, *bytes.Buffer, error) {
+	config, err := m.functionConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	jsonConfig, err := json.Marshal(config)
+	if err != nil {
+		return "", nil, err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writer.WriteField("functionConfig", string(jsonConfig)); err != nil {
+		return "", nil, err
+	}
+	if withPackage {
+		if packagePath := m.PackagePath.ValueString(); packagePath != "" {
+			content, err := os.ReadFile(packagePath)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to read function package: %w", err)
+			}
+			part, err := writer.CreateFormFile("data", filepath.Base(packagePath))
+			if err != nil {
+				return "", nil, err
+			}
+			if _, err := part.Write(content); err != nil {
+				return "", nil, err
+			}
+			// the uploaded content must be the planned one, the hash is only unknown if the path wasn't known when planning
+			hash := fmt.Sprintf("%x", sha256.Sum256(content))
+			if !m.PackageHash.IsUnknown() && !m.PackageHash.IsNull() && m.PackageHash.ValueString() != hash {
+				return "", nil, fmt.Errorf("%w: %s has hash %s, but %s was planned, run `terraform apply` again to deploy the current package",
+					errFunctionPackageChanged, packagePath, hash, m.PackageHash.ValueString())
+			}
+			m.PackageHash = types.StringValue(hash)
+		} else if archive := m.Archive.ValueString(); archive != "" && !strings.HasPrefix(archive, builtinArchivePrefix) {
+			if err := writer.WriteField("url", archive); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", nil, err
+	}
+	return writer.FormDataContentType(), body, nil
+}
+
+// requiresPackageUpdate returns true if the package of the function changed.
+func (m *StreamingFunctionResourceModel) requiresPackageUpdate(state *StreamingFunctionResourceModel) bool {
+	return !m.Archive.Equal(state.Archive) ||
+		!m.PackagePath.Equal(state.PackagePath) ||
+		!m.PackageHash.Equal(state.PackageHash)
+}
+
+// requiresFunctionUpdate returns true if any of the attributes which can be updated in place changed.
+func (m *StreamingFunctionResourceModel) requiresFunctionUpdate(state *StreamingFunctionResourceModel) bool {
+	return m.requiresPackageUpdate(state) ||
+		!m.ClassName.Equal(state.ClassName) ||
+		!m.LogTopic.Equal(state.LogTopic) ||
+		!m.UserConfig.Equal(state.UserConfig) ||
+		!m.Parallelism.Equal(state.Parallelism) ||
+		!reflect.DeepEqual(m.Resources, state.Resources) ||
+		!reflect.DeepEqual(m.Secrets, state.Secrets)
+}
+
+func (m *StreamingFunctionResourceModel) setID() {
+	m.ID = types.StringValue(strings.Join([]string{m.Cluster.ValueString(), m.TenantName.ValueString(), m.Namespace.ValueString(), m.FunctionName.ValueString()}, "/"))
+}
+
+// setStreamingFunctionData copies the data from the REST API endpoint response to the Terraform resource model.
+func setStreamingFunctionData(function streamingFunctionConfig, data *StreamingFunctionResourceModel) {
+	if function.Jar != nil && strings.HasPrefix(*function.Jar, builtinArchivePrefix) && data.PackagePath.IsNull() {
+		data.Archive = types.StringPointerValue(function.Jar)
+	}
+	if function.Runtime != nil && *function.Runtime != "" {
+		data.Runtime = types.StringPointerValue(function.Runtime)
+	}
+	if function.ClassName != nil && *function.ClassName != "" {
+		data.ClassName = types.StringPointerValue(function.ClassName)
+	}
+	if data.Inputs == nil && data.TopicsPattern.IsNull() && data.InputSpecs == nil {
+		// the inputs are only read back when imported, Pulsar merges all of them in the input specs
+		var specs map[string]astrastreaming.ConsumerConfig
+		if function.InputSpecs != nil {
+			specs = *function.InputSpecs
+		}
+		inputs, topicsPattern := pulsarImportedInputs(function.Inputs, function.TopicsPattern, specs)
+		data.Inputs = inputs
+		data.TopicsPattern = types.StringPointerValue(topicsPattern)
+	}
+	if function.Output != nil && *function.Output != "" {
+		data.Output = types.StringPointerValue(function.Output)
+	}
+	if function.OutputSchemaType != nil && *function.OutputSchemaType != "" {
+		data.OutputSchemaType = types.StringPointerValue(function.OutputSchemaType)
+	}
+	if function.LogTopic != nil && *function.LogTopic != "" {
+		data.LogTopic = types.StringPointerValue(function.LogTopic)
+	} else {
+		data.LogTopic = types.StringNull()
+	}
+	if len(function.UserConfig) == 0 {
+		data.UserConfig = types.StringNull()
+	} else if jsonConfig, err := json.Marshal(function.UserConfig); err == nil && !jsonEquivalent(data.UserConfig.ValueString(), string(jsonConfig)) {
+		// keep the configured formatting when the configuration didn't change
+		data.UserConfig = types.StringValue(string(jsonConfig))
+	}
+	if function.ProcessingGuarantees != nil {
+		data.ProcessingGuarantees = types.StringPointerValue(function.ProcessingGuarantees)
+	}
+	if function.RetainOrdering != nil {
+		data.RetainOrdering = types.BoolPointerValue(function.RetainOrdering)
+	}
+	if function.AutoAck != nil {
+		data.AutoAck = types.BoolPointerValue(function.AutoAck)
+	}
+	if function.Parallelism != nil {
+		data.Parallelism = types.Int32PointerValue(function.Parallelism)
+	}
+
+	// Pulsar fills in defaults for the optional settings, only the configured ones are refreshed
+	if !data.SubscriptionName.IsNull() {
+		data.SubscriptionName = types.StringPointerValue(function.SubName)
+	}
+	if !data.SubscriptionPosition.IsNull() && function.SubscriptionPosition != nil && *function.SubscriptionPosition != "" {
+		data.SubscriptionPosition = types.StringPointerValue(function.SubscriptionPosition)
+	}
+	if !data.MaxMessageRetries.IsNull() {
+		data.MaxMessageRetries = types.Int32PointerValue(function.MaxMessageRetries)
+	}
+	if !data.DeadLetterTopic.IsNull() {
+		data.DeadLetterTopic = types.StringPointerValue(function.DeadLetterTopic)
+	}
+	data.Resources = refreshPulsarConnectorResources(data.Resources, function.Resources)
+	data.setID()
+}
@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccStreamingFunction(t *testing.T) {
+	// Disable this test by default until test works with non-prod clusters
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_FUNCTION_TEST_ENABLED")
+
+	tenantName := fmt.Sprintf("terraform-test-%s", uuid.New().String())[0:20]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingFunctionConfiguration(tenantName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_function.streaming_function_1", "num_running", "1"),
+				),
+			},
+			{
+				// parallelism is updated in place
+				Config: testAccStreamingFunctionConfiguration(tenantName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_function.streaming_function_1", "num_running", "2"),
+				),
+			},
+			{
+				ResourceName:            "astra_streaming_function.streaming_function_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "user_config", "num_restarts", "instances", "latest_error"},
+			},
+		},
+	})
+}
+
+func testAccStreamingFunctionConfiguration(tenantName string, parallelism int) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_tenant" "streaming_tenant_1" {
+  deletion_protection = false
+  tenant_name         = "%s"
+  topic               = "terraformtest"
+  cloud_provider      = "gcp"
+  region              = "us-east4"
+  user_email          = "test@datastax.com"
+}
+
+resource "astra_streaming_topic" "input" {
+  deletion_protection   = false
+  cluster               = astra_streaming_tenant.streaming_tenant_1.cluster_name
+  tenant                = astra_streaming_tenant.streaming_tenant_1.tenant_name
+  namespace             = "default"
+  topic                 = "terraform-function-input"
+}
+
+resource "astra_streaming_topic" "output" {
+  deletion_protection   = false
+  cluster               = astra_streaming_tenant.streaming_tenant_1.cluster_name
+  tenant                = astra_streaming_tenant.streaming_tenant_1.tenant_name
+  namespace             = "default"
+  topic                 = "terraform-function-output"
+}
+
+resource "astra_streaming_function" "streaming_function_1" {
+  deletion_protection   = false
+  cluster               = astra_streaming_tenant.streaming_tenant_1.cluster_name
+  tenant_name           = astra_streaming_tenant.streaming_tenant_1.tenant_name
+  namespace             = "default"
+  function_name         = "drop-fields"
+  archive               = "builtin://transforms"
+  class_name            = "com.datastax.oss.pulsar.functions.transforms.TransformFunction"
+  inputs                = [astra_streaming_topic.input.topic_fqn]
+  output                = astra_streaming_topic.output.topic_fqn
+  parallelism           = %d
+  user_config           = jsonencode({
+    "steps": [{"type": "drop-fields", "fields": "password"}]
+  })
+}
+`, tenantName, parallelism)
+}
+
+func TestStreamingFunctionFormBody(t *testing.T) {
+	packagePath := filepath.Join(t.TempDir(), "my-function.jar")
+	assert.Nil(t, os.WriteFile(packagePath, []byte("jar content"), 0o600))
+
+	model := &StreamingFunctionResourceModel{
+		TenantName:           types.StringValue("my-tenant"),
+		Namespace:            types.StringValue("default"),
+		FunctionName:         types.StringValue("my-function"),
+		PackagePath:          types.StringValue(packagePath),
+		Runtime:              types.StringValue("JAVA"),
+		ClassName:            types.StringValue("com.example.MyFunction"),
+		Inputs:               []string{"persistent://my-tenant/default/in"},
+		Output:               types.StringValue("persistent://my-tenant/default/out"),
+		UserConfig:           types.StringValue(`{"threshold": 10}`),
+		ProcessingGuarantees: types.StringValue("ATLEAST_ONCE"),
+		Parallelism:          types.Int32Value(2),
+	}
+	contentType, body, err := model.functionFormBody(true)
+	assert.Nil(t, err)
+	form := readMultipartForm(t, contentType, body)
+	assert.JSONEq(t, `{
+		"tenant": "my-tenant",
+		"namespace": "default",
+		"name": "my-function",
+		"className": "com.example.MyFunction",
+		"runtime": "JAVA",
+		"inputs": ["persistent://my-tenant/default/in"],
+		"output": "persistent://my-tenant/default/out",
+		"userConfig": {"threshold": 10},
+		"processingGuarantees": "ATLEAST_ONCE",
+		"parallelism": 2
+	}`, form["functionConfig"])
+	assert.Equal(t, "jar content", form["data"])
+	hash, err := functionPackageHash(packagePath)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, model.PackageHash.ValueString())
+
+	// the package must not change between the plan and the apply
+	assert.Nil(t, os.WriteFile(packagePath, []byte("new jar content"), 0o600))
+	_, _, err = model.functionFormBody(true)
+	assert.ErrorIs(t, err, errFunctionPackageChanged)
+	assert.Equal(t, hash, model.PackageHash.ValueString())
+
+	// the package is not uploaded again when it didn't change
+	contentType, body, err = model.functionFormBody(false)
+	assert.Nil(t, err)
+	form = readMultipartForm(t, contentType, body)
+	assert.NotContains(t, form, "data")
+
+	// built-in archives are referenced by the configuration, other archives by URL
+	model.PackagePath = types.StringNull()
+	model.Archive = types.StringValue("builtin://transforms")
+	contentType, body, err = model.functionFormBody(true)
+	assert.Nil(t, err)
+	form = readMultipartForm(t, contentType, body)
+	assert.NotContains(t, form, "url")
+	assert.Contains(t, form["functionConfig"], `"jar":"builtin://transforms"`)
+
+	model.Archive = types.StringValue("function://my-tenant/default/my-package@1")
+	contentType, body, err = model.functionFormBody(true)
+	assert.Nil(t, err)
+	form = readMultipartForm(t, contentType, body)
+	assert.Equal(t, "function://my-tenant/default/my-package@1", form["url"])
+
+	model.UserConfig = types.StringValue("not json")
+	_, _, err = model.functionFormBody(true)
+	assert.NotNil(t, err)
+}
+
+// readMultipartForm returns the content of each part of a multipart form, keyed by form name.
+func readMultipartForm(t *testing.T, contentType string, body io.Reader) map[string]string {
+	_, params, err := mime.ParseMediaType(contentType)
+	assert.Nil(t, err)
+	reader := multipart.NewReader(body, params["boundary"])
+	form := map[string]string{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return form
+		}
+		assert.Nil(t, err)
+		content, err := io.ReadAll(part)
+		assert.Nil(t, err)
+		form[part.FormName()] = string(content)
+	}
+}
+
+func TestSetStreamingFunctionData(t *testing.T) {
+	var function streamingFunctionConfig
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"tenant": "my-tenant",
+		"namespace": "default",
+		"name": "my-function",
+		"className": "com.datastax.oss.pulsar.functions.transforms.TransformFunction",
+		"runtime": "JAVA",
+		"jar": "builtin://transforms",
+		"inputSpecs": {
+			"persistent://my-tenant/default/b": {"schemaType": "", "regexPattern": false},
+			"persistent://my-tenant/default/a": {"schemaType": "", "regexPattern": false}
+		},
+		"output": "persistent://my-tenant/default/out",
+		"logTopic": "",
+		"userConfig": {"steps": [{"type": "drop-fields", "fields": "password"}]},
+		"processingGuarantees": "ATLEAST_ONCE",
+		"retainOrdering": false,
+		"autoAck": true,
+		"subName": "my-function",
+		"parallelism": 3,
+		"resources": {"cpu": 1.0, "ram": 1073741824, "disk": 10737418240}
+	}`), &function))
+
+	// imported function
+	data := &StreamingFunctionResourceModel{
+		Cluster:      types.StringValue("pulsar-gcp-useast1"),
+		TenantName:   types.StringValue("my-tenant"),
+		Namespace:    types.StringValue("default"),
+		FunctionName: types.StringValue("my-function"),
+	}
+	setStreamingFunctionData(function, data)
+	assert.Equal(t, "pulsar-gcp-useast1/my-tenant/default/my-function", data.ID.ValueString())
+	assert.Equal(t, "builtin://transforms", data.Archive.ValueString())
+	assert.Equal(t, []string{"persistent://my-tenant/default/a", "persistent://my-tenant/default/b"}, data.Inputs)
+	assert.Equal(t, "persistent://my-tenant/default/out", data.Output.ValueString())
+	assert.True(t, data.LogTopic.IsNull())
+	assert.JSONEq(t, `{"steps": [{"type": "drop-fields", "fields": "password"}]}`, data.UserConfig.ValueString())
+	assert.True(t, data.SubscriptionName.IsNull())
+	assert.Equal(t, int32(3), data.Parallelism.ValueInt32())
+	assert.Nil(t, data.Resources)
+
+	// configured inputs and formatting are kept
+	userConfig := `{ "steps": [ { "fields": "password", "type": "drop-fields" } ] }`
+	data.Inputs = []string{"persistent://my-tenant/default/a"}
+	data.UserConfig = types.StringValue(userConfig)
+	setStreamingFunctionData(function, data)
+	assert.Equal(t, []string{"persistent://my-tenant/default/a"}, data.Inputs)
+	assert.Equal(t, userConfig, data.UserConfig.ValueString())
+}
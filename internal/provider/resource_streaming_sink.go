@@ -5,27 +5,42 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &StreamingSinkResource{}
-	_ resource.ResourceWithConfigure = &StreamingSinkResource{}
-	//_ resource.ResourceWithImportState = &StreamingTenantResource{}
+	_ resource.Resource                     = &StreamingSinkResource{}
+	_ resource.ResourceWithConfigure        = &StreamingSinkResource{}
+	_ resource.ResourceWithConfigValidators = &StreamingSinkResource{}
+	_ resource.ResourceWithModifyPlan       = &StreamingSinkResource{}
+	_ resource.ResourceWithImportState      = &StreamingSinkResource{}
 )
 
 // NewStreamingTenantResource is a helper function to simplify the provider implementation.
@@ -39,22 +54,38 @@ type StreamingSinkResource struct {
 }
 
 type StreamingSinkResourceModel struct {
-	ID                   types.String `tfsdk:"id"` // Unique ID in the form cluster_name/tenant_name
-	Cluster              types.String `tfsdk:"cluster"`
-	PulsarClusterName    types.String `tfsdk:"pulsar_cluster"`
-	CloudProvider        types.String `tfsdk:"cloud_provider"`
-	Region               types.String `tfsdk:"region"`
-	TenantName           types.String `tfsdk:"tenant_name"`
-	Namespace            types.String `tfsdk:"namespace"`
-	SinkName             types.String `tfsdk:"sink_name"`
-	Archive              types.String `tfsdk:"archive"`
-	Topic                types.String `tfsdk:"topic"`
-	RetainOrdering       types.Bool   `tfsdk:"retain_ordering"`
-	ProcessingGuarantees types.String `tfsdk:"processing_guarantees"`
-	Parallelism          types.Int32  `tfsdk:"parallelism"`
-	SinkConfigs          types.String `tfsdk:"sink_configs"`
-	AutoAck              types.Bool   `tfsdk:"auto_ack"`
-	DeletionProtection   types.Bool   `tfsdk:"deletion_protection"`
+	ID                   types.String   `tfsdk:"id"` // Unique ID in the form cluster_name/tenant_name
+	Cluster              types.String   `tfsdk:"cluster"`
+	PulsarClusterName    types.String   `tfsdk:"pulsar_cluster"`
+	CloudProvider        types.String   `tfsdk:"cloud_provider"`
+	Region               types.String   `tfsdk:"region"`
+	TenantName           types.String   `tfsdk:"tenant_name"`
+	Namespace            types.String   `tfsdk:"namespace"`
+	SinkName             types.String   `tfsdk:"sink_name"`
+	Archive              types.String   `tfsdk:"archive"`
+	Topic                types.String   `tfsdk:"topic"`
+	RetainOrdering       types.Bool     `tfsdk:"retain_ordering"`
+	ProcessingGuarantees types.String   `tfsdk:"processing_guarantees"`
+	Parallelism          types.Int32    `tfsdk:"parallelism"`
+	SinkConfigs          types.String   `tfsdk:"sink_configs"`
+	AutoAck              types.Bool     `tfsdk:"auto_ack"`
+	DeletionProtection   types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+
+	Inputs                       []string                         `tfsdk:"inputs"`
+	TopicsPattern                types.String                     `tfsdk:"topics_pattern"`
+	InputSpecs                   map[string]pulsarConsumerSpec    `tfsdk:"input_specs"`
+	SubscriptionName             types.String                     `tfsdk:"subscription_name"`
+	SubscriptionPosition         types.String                     `tfsdk:"subscription_position"`
+	DeadLetterTopic              types.String                     `tfsdk:"dead_letter_topic"`
+	MaxMessageRetries            types.Int32                      `tfsdk:"max_message_retries"`
+	NegativeAckRedeliveryDelayMs types.Int64                      `tfsdk:"negative_ack_redelivery_delay_ms"`
+	TimeoutMs                    types.Int64                      `tfsdk:"timeout_ms"`
+	Resources                    *pulsarConnectorResources        `tfsdk:"resources"`
+	Secrets                      map[string]pulsarConnectorSecret `tfsdk:"secrets"`
+
+	WaitForRunning types.Bool `tfsdk:"wait_for_running"`
+	pulsarConnectorStatusModel
 }
 
 // Metadata returns the data source type name.
@@ -62,7 +93,7 @@ func (r *StreamingSinkResource) Metadata(_ context.Context, req resource.Metadat
 	resp.TypeName = req.ProviderTypeName + "_streaming_sink"
 }
 
-func (r *StreamingSinkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *StreamingSinkResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Creates a streaming sink which sends data from a topic to a target system.",
 		Attributes: map[string]schema.Attribute{
@@ -74,7 +105,7 @@ func (r *StreamingSinkResource) Schema(_ context.Context, _ resource.SchemaReque
 				Description: "Name of the pulsar cluster in which to create the sink. If left blank, the name will be inferred from the cloud provider and region.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceIfSinkClusterChange(),
 				},
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(2, 32),
@@ -85,7 +116,7 @@ func (r *StreamingSinkResource) Schema(_ context.Context, _ resource.SchemaReque
 				Description:        "Name of the pulsar cluster in which to create the sink. If left blank, the name will be inferred from the cloud provider and region.",
 				Optional:           true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceIfSinkClusterChange(),
 				},
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(2, 32),
@@ -96,7 +127,7 @@ func (r *StreamingSinkResource) Schema(_ context.Context, _ resource.SchemaReque
 				Description:        "Cloud provider (deprecated, use `cluster` instead)",
 				Optional:           true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceIfSinkClusterChange(),
 				},
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(2, 32),
@@ -107,7 +138,7 @@ func (r *StreamingSinkResource) Schema(_ context.Context, _ resource.SchemaReque
 				Description:        "cloud region (deprecated, use `cluster` instead)",
 				Optional:           true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceIfSinkClusterChange(),
 				},
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(2, 32),
@@ -150,8 +181,8 @@ func (r *StreamingSinkResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 			},
 			"topic": schema.StringAttribute{
-				Description: "Streaming tenant topic.",
-				Required:    true,
+				Description: "Streaming tenant topic. At least one of `topic`, `inputs`, `topics_pattern` or `input_specs` must be set.",
+				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -171,25 +202,108 @@ func (r *StreamingSinkResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 			},
 			"parallelism": schema.Int32Attribute{
-				Description: "Parallelism for Pulsar sink",
+				Description: "Parallelism for Pulsar sink. Changing the parallelism updates the sink in place.",
 				Required:    true,
-				PlanModifiers: []planmodifier.Int32{
-					int32planmodifier.RequiresReplace(),
-				},
 			},
 			"sink_configs": schema.StringAttribute{
-				Description: "Sink Configs",
+				Description: "Sink Configs, as a JSON string. Changing the configs updates the sink in place, formatting and key order are ignored.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					planModifierSuppressJSONDiff(),
 				},
 			},
 			"auto_ack": schema.BoolAttribute{
 				Description: "auto ack",
 				Required:    true,
-				// PlanModifiers: []planmodifier.Bool{
-				// 	boolplanmodifier.RequiresReplace(),
-				// },
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"inputs": schema.ListAttribute{
+				Description: "Input topics of the sink, for sinks consuming from several topics.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"topics_pattern": schema.StringAttribute{
+				Description: "Regular expression matching the input topics of the sink, e.g. 'persistent://my-tenant/my-namespace/orders-.*'.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"input_specs": pulsarConsumerSpecsSchema(mapplanmodifier.RequiresReplace()),
+			"subscription_name": schema.StringAttribute{
+				Description: "Name of the subscription used to consume the input topics. Defaults to a name derived from the sink.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subscription_position": schema.StringAttribute{
+				Description: "Initial position of the subscription, \"Latest\" or \"Earliest\". Defaults to \"Latest\".",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("Latest", "Earliest"),
+				},
+			},
+			"dead_letter_topic": schema.StringAttribute{
+				Description: "Topic which the messages are sent to after `max_message_retries` failed attempts.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("max_message_retries")),
+				},
+			},
+			"max_message_retries": schema.Int32Attribute{
+				Description: "Number of times a message is redelivered before it is dropped, or sent to the `dead_letter_topic`.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"negative_ack_redelivery_delay_ms": schema.Int64Attribute{
+				Description: "Delay, in milliseconds, before a negatively acknowledged message is redelivered.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"timeout_ms": schema.Int64Attribute{
+				Description: "Timeout, in milliseconds, after which unacknowledged messages are redelivered.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"resources": pulsarConnectorResourcesSchema(),
+			"secrets":   pulsarConnectorSecretsSchema(),
+			"wait_for_running": schema.BoolAttribute{
+				Description: "Whether to wait for all the instances of the sink to be running after it is created or updated. " +
+					"The create or update fails if the instances are not running before the timeout. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
 			"deletion_protection": schema.BoolAttribute{
 				Description: "Whether or not to allow Terraform to destroy this streaming sink. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the instance will fail. Defaults to `true`.",
@@ -198,6 +312,34 @@ func (r *StreamingSinkResource) Schema(_ context.Context, _ resource.SchemaReque
 				Default:     booldefault.StaticBool(true),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+	for name, attribute := range pulsarConnectorStatusAttributes("sink") {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (r *StreamingSinkResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("topic"),
+			path.MatchRoot("inputs"),
+			path.MatchRoot("topics_pattern"),
+			path.MatchRoot("input_specs"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("topics_pattern"),
+			path.MatchRoot("topic"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("topics_pattern"),
+			path.MatchRoot("inputs"),
+		),
 	}
 }
 
@@ -210,6 +352,50 @@ func (r *StreamingSinkResource) Configure(_ context.Context, req resource.Config
 	r.clients = req.ProviderData.(*astraClients2)
 }
 
+// ModifyPlan validates the sink archive and configs against the connectors of the cluster. Only built-in archives are
+// validated, and the validation is skipped if the connectors can't be retrieved.
+func (r *StreamingSinkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.clients == nil {
+		// resource is being destroyed, or the provider is not configured yet
+		return
+	}
+
+	plan := &StreamingSinkResourceModel{}
+	for attr, target := range map[string]interface{}{
+		"cluster":        &plan.Cluster,
+		"pulsar_cluster": &plan.PulsarClusterName,
+		"cloud_provider": &plan.CloudProvider,
+		"region":         &plan.Region,
+		"sink_name":      &plan.SinkName,
+		"archive":        &plan.Archive,
+		"sink_configs":   &plan.SinkConfigs,
+	} {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr), target)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		state := &StreamingSinkResourceModel{}
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("archive"), &state.Archive)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("sink_configs"), &state.SinkConfigs)...)
+		if resp.Diagnostics.HasError() || (plan.Archive.Equal(state.Archive) && plan.SinkConfigs.Equal(state.SinkConfigs)) {
+			return
+		}
+	}
+
+	archive := plan.Archive.ValueString()
+	if plan.Archive.IsUnknown() && !plan.SinkName.IsUnknown() {
+		// archive defaults to the sink name
+		archive = builtinArchivePrefix + plan.SinkName.ValueString()
+	}
+	if plan.Cluster.IsUnknown() || plan.PulsarClusterName.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(validateBuiltinConnector(ctx, r.clients.astraStreamingClient, plan.getClusterName(), "sinks", archive, plan.SinkConfigs, path.Root("sink_configs"))...)
+}
+
 func (r *StreamingSinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	plan := &StreamingSinkResourceModel{}
 	diags := req.Plan.Get(ctx, plan)
@@ -271,38 +457,7 @@ func (r *StreamingSinkResource) Create(ctx context.Context, req resource.CreateR
 		Authorization:          r.clients.token,
 	}
 
-	sinkInputs := []string{plan.Topic.ValueString()}
-	createSinkBody := astrastreaming.CreateSinkJSONJSONRequestBody{
-		Archive:                      plan.Archive.ValueStringPointer(),
-		AutoAck:                      plan.AutoAck.ValueBoolPointer(),
-		ClassName:                    nil,
-		CleanupSubscription:          nil,
-		Configs:                      &configs,
-		CustomRuntimeOptions:         nil,
-		DeadLetterTopic:              nil,
-		InputSpecs:                   nil,
-		Inputs:                       &sinkInputs,
-		MaxMessageRetries:            nil,
-		Name:                         &sinkName,
-		Namespace:                    &namespace,
-		NegativeAckRedeliveryDelayMs: nil,
-		Parallelism:                  plan.Parallelism.ValueInt32Pointer(),
-		ProcessingGuarantees:         (*astrastreaming.SinkConfigProcessingGuarantees)(plan.ProcessingGuarantees.ValueStringPointer()),
-		Resources:                    nil,
-		RetainKeyOrdering:            nil,
-		RetainOrdering:               plan.RetainOrdering.ValueBoolPointer(),
-		RuntimeFlags:                 nil,
-		Secrets:                      nil,
-		SinkType:                     nil,
-		SourceSubscriptionName:       nil,
-		SourceSubscriptionPosition:   nil,
-		Tenant:                       &tenantName,
-		TimeoutMs:                    nil,
-		TopicToSchemaProperties:      nil,
-		TopicToSchemaType:            nil,
-		TopicToSerdeClassName:        nil,
-		TopicsPattern:                nil,
-	}
+	createSinkBody := plan.sinkConfig(configs)
 
 	sinkCreationResponse, err := astraStreamingClient.CreateSinkJSON(ctx, tenantName, namespace, sinkName, &createSinkParams, createSinkBody)
 	if err != nil {
@@ -320,7 +475,20 @@ func (r *StreamingSinkResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	plan.setID()
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultConnectorRunningTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// the sink is saved even if it is not running, so that it is tainted rather than lost
+	err = r.refreshSinkStatus(ctx, plan, plan.WaitForRunning.ValueBool(), nil, createTimeout, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"sink is not running after create",
+			err.Error())
+	}
 }
 
 func (r *StreamingSinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -372,13 +540,28 @@ func (r *StreamingSinkResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	priorSinkConfigs := state.SinkConfigs
 	setStreamingSinkData(sinkResponseData, state)
+	// keep the configured formatting when the configs didn't change
+	if jsonEquivalent(priorSinkConfigs.ValueString(), state.SinkConfigs.ValueString()) {
+		state.SinkConfigs = priorSinkConfigs
+	}
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(true)
+	}
+
+	status, err := getPulsarConnectorStatus(ctx, astraStreamingClient, streamingClusterName, "sinks", tenantName, namespace, sinkName)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("failed to get sink status: %v", err))
+	} else {
+		resp.Diagnostics.Append(state.setStatus(ctx, status)...)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 
 }
 
-// Update currently only handles updating the deletion protection field.  TODO: add support for updating sink config.
+// Update updates the sink in place when its configuration changed, and waits for its instances to be running again.
 func (r *StreamingSinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	plan := &StreamingSinkResourceModel{}
 	diags := req.Plan.Get(ctx, plan)
@@ -391,9 +574,67 @@ func (r *StreamingSinkResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	state.DeletionProtection = plan.DeletionProtection
-	state.setID()
-	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultConnectorRunningTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated := plan.requiresSinkUpdate(state)
+	var previousStatus *pulsarConnectorStatus
+	if updated {
+		if plan.WaitForRunning.ValueBool() {
+			// the instances of the previous deployment are still reported as running right after the update
+			previousStatus, _ = getPulsarConnectorStatus(ctx, r.clients.astraStreamingClient, state.getClusterName(), "sinks",
+				state.TenantName.ValueString(), state.Namespace.ValueString(), state.SinkName.ValueString())
+		}
+
+		orgID, err := getCurrentOrgID(ctx, r.clients.astraClient)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"failed to get current OrgID",
+				err.Error())
+			return
+		}
+
+		var configs map[string]interface{}
+		if err := json.Unmarshal([]byte(plan.SinkConfigs.ValueString()), &configs); err != nil {
+			resp.Diagnostics.AddError(
+				"invalid sink config",
+				err.Error())
+			return
+		}
+
+		updateSinkParams := astrastreaming.UpdateSinkJSONParams{
+			XDataStaxPulsarCluster: state.getClusterName(),
+			XDataStaxCurrentOrg:    orgID,
+			Authorization:          r.clients.token,
+		}
+		sinkUpdateResponse, err := r.clients.astraStreamingClient.UpdateSinkJSON(ctx, state.TenantName.ValueString(), state.Namespace.ValueString(),
+			state.SinkName.ValueString(), &updateSinkParams, plan.sinkConfig(configs))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"failed to update sink",
+				err.Error())
+			return
+		} else if sinkUpdateResponse.StatusCode > 299 {
+			body, _ := io.ReadAll(sinkUpdateResponse.Body)
+			errMsg := fmt.Sprintf("failed to update sink, status code: %d, message: %s", sinkUpdateResponse.StatusCode, string(body))
+			resp.Diagnostics.AddError(
+				"failed to update sink",
+				errMsg)
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	err := r.refreshSinkStatus(ctx, plan, updated && plan.WaitForRunning.ValueBool(), previousStatus, updateTimeout, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"sink is not running after update",
+			err.Error())
+	}
 }
 
 func (r *StreamingSinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -445,38 +686,165 @@ func (r *StreamingSinkResource) Delete(ctx context.Context, req resource.DeleteR
 	resp.State.RemoveResource(ctx)
 }
 
+// ImportState reads the ID from the CLI and then calls Read() to get the state of the sink
+func (r *StreamingSinkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !sinkIDRegex.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Error importing sink",
+			"ID must be in the format <cluster>/<tenant>/<namespace>/<sink_name>",
+		)
+		return
+	}
+	sinkID := strings.Split(req.ID, "/")
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), sinkID[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_name"), sinkID[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), sinkID[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sink_name"), sinkID[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_running"), true)...)
+}
+
 type SinkResponse struct {
-	Tenant                       string                 `json:"tenant"`
-	Namespace                    string                 `json:"namespace"`
-	Name                         string                 `json:"name"`
-	ClassName                    string                 `json:"className"`
-	SourceSubscriptionName       interface{}            `json:"sourceSubscriptionName"`
-	SourceSubscriptionPosition   string                 `json:"sourceSubscriptionPosition"`
-	Inputs                       interface{}            `json:"inputs"`
-	TopicToSerdeClassName        interface{}            `json:"topicToSerdeClassName"`
-	TopicsPattern                interface{}            `json:"topicsPattern"`
-	TopicToSchemaType            interface{}            `json:"topicToSchemaType"`
-	TopicToSchemaProperties      interface{}            `json:"topicToSchemaProperties"`
-	MaxMessageRetries            interface{}            `json:"maxMessageRetries"`
-	DeadLetterTopic              interface{}            `json:"deadLetterTopic"`
-	Configs                      map[string]interface{} `json:"configs"`
-	Secrets                      interface{}            `json:"secrets"`
-	Parallelism                  int                    `json:"parallelism"`
-	ProcessingGuarantees         string                 `json:"processingGuarantees"`
-	RetainOrdering               bool                   `json:"retainOrdering"`
-	RetainKeyOrdering            bool                   `json:"retainKeyOrdering"`
-	Resources                    interface{}            `json:"resources"`
-	AutoAck                      bool                   `json:"autoAck"`
-	TimeoutMs                    interface{}            `json:"timeoutMs"`
-	NegativeAckRedeliveryDelayMs interface{}            `json:"negativeAckRedeliveryDelayMs"`
-	Archive                      string                 `json:"archive"`
-	CleanupSubscription          interface{}            `json:"cleanupSubscription"`
-	RuntimeFlags                 interface{}            `json:"runtimeFlags"`
-	CustomRuntimeOptions         interface{}            `json:"customRuntimeOptions"`
+	Tenant                       string                                   `json:"tenant"`
+	Namespace                    string                                   `json:"namespace"`
+	Name                         string                                   `json:"name"`
+	ClassName                    string                                   `json:"className"`
+	SourceSubscriptionName       *string                                  `json:"sourceSubscriptionName"`
+	SourceSubscriptionPosition   string                                   `json:"sourceSubscriptionPosition"`
+	Inputs                       []string                                 `json:"inputs"`
+	InputSpecs                   map[string]astrastreaming.ConsumerConfig `json:"inputSpecs"`
+	TopicToSerdeClassName        interface{}                              `json:"topicToSerdeClassName"`
+	TopicsPattern                *string                                  `json:"topicsPattern"`
+	TopicToSchemaType            interface{}                              `json:"topicToSchemaType"`
+	TopicToSchemaProperties      interface{}                              `json:"topicToSchemaProperties"`
+	MaxMessageRetries            *int32                                   `json:"maxMessageRetries"`
+	DeadLetterTopic              *string                                  `json:"deadLetterTopic"`
+	Configs                      map[string]interface{}                   `json:"configs"`
+	Secrets                      interface{}                              `json:"secrets"`
+	Parallelism                  int                                      `json:"parallelism"`
+	ProcessingGuarantees         string                                   `json:"processingGuarantees"`
+	RetainOrdering               bool                                     `json:"retainOrdering"`
+	RetainKeyOrdering            bool                                     `json:"retainKeyOrdering"`
+	Resources                    *astrastreaming.Resources                `json:"resources"`
+	AutoAck                      bool                                     `json:"autoAck"`
+	TimeoutMs                    *int64                                   `json:"timeoutMs"`
+	NegativeAckRedeliveryDelayMs *int64                                   `json:"negativeAckRedeliveryDelayMs"`
+	Archive                      string                                   `json:"archive"`
+	CleanupSubscription          interface{}                              `json:"cleanupSubscription"`
+	RuntimeFlags                 interface{}                              `json:"runtimeFlags"`
+	CustomRuntimeOptions         interface{}                              `json:"customRuntimeOptions"`
+}
+
+// sinkConfig returns the sink configuration of the model, with the given configs.
+func (m *StreamingSinkResourceModel) sinkConfig(configs map[string]interface{}) astrastreaming.SinkConfig {
+	tenantName := m.TenantName.ValueString()
+	namespace := m.Namespace.ValueString()
+	sinkName := m.SinkName.ValueString()
+	var sinkInputs *[]string
+	if inputs := m.inputTopics(); len(inputs) > 0 {
+		sinkInputs = &inputs
+	}
+	return astrastreaming.SinkConfig{
+		Archive:                      m.Archive.ValueStringPointer(),
+		AutoAck:                      m.AutoAck.ValueBoolPointer(),
+		ClassName:                    nil,
+		CleanupSubscription:          nil,
+		Configs:                      &configs,
+		CustomRuntimeOptions:         nil,
+		DeadLetterTopic:              m.DeadLetterTopic.ValueStringPointer(),
+		InputSpecs:                   pulsarConsumerConfigs(m.InputSpecs),
+		Inputs:                       sinkInputs,
+		MaxMessageRetries:            m.MaxMessageRetries.ValueInt32Pointer(),
+		Name:                         &sinkName,
+		Namespace:                    &namespace,
+		NegativeAckRedeliveryDelayMs: m.NegativeAckRedeliveryDelayMs.ValueInt64Pointer(),
+		Parallelism:                  m.Parallelism.ValueInt32Pointer(),
+		ProcessingGuarantees:         (*astrastreaming.SinkConfigProcessingGuarantees)(m.ProcessingGuarantees.ValueStringPointer()),
+		Resources:                    m.Resources.apiResources(),
+		RetainKeyOrdering:            nil,
+		RetainOrdering:               m.RetainOrdering.ValueBoolPointer(),
+		RuntimeFlags:                 nil,
+		Secrets:                      pulsarConnectorSecrets(m.Secrets),
+		SinkType:                     nil,
+		SourceSubscriptionName:       m.SubscriptionName.ValueStringPointer(),
+		SourceSubscriptionPosition:   (*astrastreaming.SinkConfigSourceSubscriptionPosition)(m.SubscriptionPosition.ValueStringPointer()),
+		Tenant:                       &tenantName,
+		TimeoutMs:                    m.TimeoutMs.ValueInt64Pointer(),
+		TopicToSchemaProperties:      nil,
+		TopicToSchemaType:            nil,
+		TopicToSerdeClassName:        nil,
+		TopicsPattern:                m.TopicsPattern.ValueStringPointer(),
+	}
+}
+
+// inputTopics returns the input topics of the sink, from the `topic` and `inputs` attributes.
+func (m *StreamingSinkResourceModel) inputTopics() []string {
+	var inputs []string
+	if m.Topic.ValueString() != "" {
+		inputs = append(inputs, m.Topic.ValueString())
+	}
+	return append(inputs, m.Inputs...)
+}
+
+// requiresSinkUpdate returns true if any of the attributes which can be updated in place changed.
+func (m *StreamingSinkResourceModel) requiresSinkUpdate(state *StreamingSinkResourceModel) bool {
+	return !m.Parallelism.Equal(state.Parallelism) ||
+		!m.SinkConfigs.Equal(state.SinkConfigs) ||
+		!reflect.DeepEqual(m.Resources, state.Resources) ||
+		!reflect.DeepEqual(m.Secrets, state.Secrets)
+}
+
+// requiresReplaceIfSinkClusterChange only requires replace if the Pulsar cluster of the sink changes. The cluster can be
+// set with `cluster`, `pulsar_cluster` or the cloud provider and region, and an imported sink only has `cluster` set.
+func requiresReplaceIfSinkClusterChange() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var state, plan StreamingSinkResourceModel
+			for _, attr := range []struct {
+				name        string
+				state, plan *types.String
+			}{
+				{"id", &state.ID, &plan.ID},
+				{"cluster", &state.Cluster, &plan.Cluster},
+				{"pulsar_cluster", &state.PulsarClusterName, &plan.PulsarClusterName},
+				{"cloud_provider", &state.CloudProvider, &plan.CloudProvider},
+				{"region", &state.Region, &plan.Region},
+			} {
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attr.name), attr.state)...)
+				resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr.name), attr.plan)...)
+			}
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			if plan.Cluster.IsUnknown() || plan.PulsarClusterName.IsUnknown() || plan.CloudProvider.IsUnknown() || plan.Region.IsUnknown() {
+				resp.RequiresReplace = true
+				return
+			}
+			// the ID of the plan is unknown, so the planned cluster is only inferred from the configured attributes
+			plannedCluster := getPulsarCluster(firstNonEmptyString(plan.Cluster.ValueString(), plan.PulsarClusterName.ValueString()),
+				plan.CloudProvider.ValueString(), removeDashes(plan.Region.ValueString()), "")
+			resp.RequiresReplace = plannedCluster != state.getClusterName()
+		},
+		"If the Pulsar cluster of the sink changes, Terraform will destroy and recreate the resource.",
+		"If the Pulsar cluster of the sink changes, Terraform will destroy and recreate the resource.",
+	)
+}
+
+// refreshSinkStatus sets the status attributes of the model from the current status of the sink, see refreshPulsarConnectorStatus.
+func (r *StreamingSinkResource) refreshSinkStatus(ctx context.Context, m *StreamingSinkResourceModel, wait bool, previous *pulsarConnectorStatus, timeout time.Duration, diags *diag.Diagnostics) error {
+	status, err := refreshPulsarConnectorStatus(ctx, r.clients.astraStreamingClient, m.getClusterName(), "sinks",
+		m.TenantName.ValueString(), m.Namespace.ValueString(), m.SinkName.ValueString(), wait, int(m.Parallelism.ValueInt32()), previous, timeout)
+	diags.Append(m.setStatus(ctx, status)...)
+	return err
 }
 
 // setStreamingSinkData copies the data from the REST API endpoint response to the Terraform resource model.
 func setStreamingSinkData(sink SinkResponse, data *StreamingSinkResourceModel) {
+	// the archive is always set once the sink is created or read, it is only null right after an import
+	imported := data.Archive.IsNull()
+
 	data.TenantName = types.StringValue(sink.Tenant)
 	data.Namespace = types.StringValue(sink.Namespace)
 	data.SinkName = types.StringValue(sink.Name)
@@ -487,14 +855,80 @@ func setStreamingSinkData(sink SinkResponse, data *StreamingSinkResourceModel) {
 	jsonSinkConfig, err := json.Marshal(sink.Configs)
 	if err != nil {
 		data.SinkConfigs = types.StringNull()
-
+	} else {
+		data.SinkConfigs = types.StringValue(string(jsonSinkConfig))
 	}
-	data.SinkConfigs = types.StringValue(string(jsonSinkConfig))
 	data.AutoAck = types.BoolValue(sink.AutoAck)
 
+	if imported {
+		setImportedStreamingSinkData(sink, data)
+	} else {
+		// Pulsar fills in defaults for the optional settings, only the configured ones are refreshed
+		if !data.SubscriptionName.IsNull() {
+			data.SubscriptionName = types.StringPointerValue(sink.SourceSubscriptionName)
+		}
+		if !data.SubscriptionPosition.IsNull() && sink.SourceSubscriptionPosition != "" {
+			data.SubscriptionPosition = types.StringValue(sink.SourceSubscriptionPosition)
+		}
+		if !data.DeadLetterTopic.IsNull() {
+			data.DeadLetterTopic = types.StringPointerValue(sink.DeadLetterTopic)
+		}
+		if !data.MaxMessageRetries.IsNull() {
+			data.MaxMessageRetries = types.Int32PointerValue(sink.MaxMessageRetries)
+		}
+		if !data.NegativeAckRedeliveryDelayMs.IsNull() {
+			data.NegativeAckRedeliveryDelayMs = types.Int64PointerValue(sink.NegativeAckRedeliveryDelayMs)
+		}
+		if !data.TimeoutMs.IsNull() {
+			data.TimeoutMs = types.Int64PointerValue(sink.TimeoutMs)
+		}
+	}
+	data.Resources = refreshPulsarConnectorResources(data.Resources, sink.Resources)
+
 	data.setID()
 }
 
+// setImportedStreamingSinkData reads back the settings of an imported sink, which only has the attributes of its ID set.
+// The settings left to the Pulsar defaults are not set, so that a configuration which doesn't set them has no diff.
+func setImportedStreamingSinkData(sink SinkResponse, data *StreamingSinkResourceModel) {
+	// the topics with custom consumer settings are imported as input specs, the other ones as inputs, and a single input
+	// topic is set as `topic`
+	data.InputSpecs = pulsarImportedInputSpecs(sink.InputSpecs)
+	var inputs []string
+	for _, input := range sink.Inputs {
+		if _, ok := data.InputSpecs[input]; !ok {
+			inputs = append(inputs, input)
+		}
+	}
+	inputSpecs := map[string]astrastreaming.ConsumerConfig{}
+	for topic, spec := range sink.InputSpecs {
+		if _, ok := data.InputSpecs[topic]; !ok {
+			inputSpecs[topic] = spec
+		}
+	}
+	inputs, topicsPattern := pulsarImportedInputs(inputs, sink.TopicsPattern, inputSpecs)
+	if len(inputs) == 1 {
+		data.Topic = types.StringValue(inputs[0])
+	} else {
+		data.Inputs = inputs
+	}
+	data.TopicsPattern = types.StringPointerValue(topicsPattern)
+
+	if sink.SourceSubscriptionName != nil && *sink.SourceSubscriptionName != "" {
+		data.SubscriptionName = types.StringPointerValue(sink.SourceSubscriptionName)
+	}
+	if sink.SourceSubscriptionPosition != "" && sink.SourceSubscriptionPosition != "Latest" {
+		data.SubscriptionPosition = types.StringValue(sink.SourceSubscriptionPosition)
+	}
+	if sink.DeadLetterTopic != nil && *sink.DeadLetterTopic != "" {
+		data.DeadLetterTopic = types.StringPointerValue(sink.DeadLetterTopic)
+	}
+	data.MaxMessageRetries = types.Int32PointerValue(sink.MaxMessageRetries)
+	data.NegativeAckRedeliveryDelayMs = types.Int64PointerValue(sink.NegativeAckRedeliveryDelayMs)
+	data.TimeoutMs = types.Int64PointerValue(sink.TimeoutMs)
+	data.Secrets = pulsarImportedSecrets(sink.Secrets)
+}
+
 func (m *StreamingSinkResourceModel) setID() {
 	clusterName := m.getClusterName()
 	m.ID = types.StringValue(clusterName + "/" + m.TenantName.ValueString() + "/" + m.Namespace.ValueString() + "/" + m.SinkName.ValueString())
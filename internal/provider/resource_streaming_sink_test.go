@@ -1,11 +1,21 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/datastax/astra-client-go/v2/astra"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccStreamingSink(t *testing.T) {
@@ -20,7 +30,17 @@ func TestAccStreamingSink(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccStreamingSinkConfiguration(tenantName),
+				Config: testAccStreamingSinkConfiguration(tenantName, 3),
+			},
+			{
+				// parallelism is updated in place
+				Config: testAccStreamingSinkConfiguration(tenantName, 2),
+			},
+			{
+				ResourceName:            "astra_streaming_sink.streaming_sink_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "sink_configs", "num_restarts", "instances", "latest_error"},
 			},
 			// {
 			// 	Config: testAccStreamingSnowflakeSinkConfiguration(snowflakeTenantName),
@@ -30,7 +50,7 @@ func TestAccStreamingSink(t *testing.T) {
 }
 
 // https://www.terraform.io/docs/extend/testing/acceptance-tests/index.html
-func testAccStreamingSinkConfiguration(tenantName string) string {
+func testAccStreamingSinkConfiguration(tenantName string, parallelism int) string {
 	return fmt.Sprintf(`
 resource "astra_streaming_tenant" "streaming_tenant_1" {
   deletion_protection = false
@@ -62,7 +82,7 @@ resource "astra_streaming_sink" "streaming_sink_1" {
   sink_name             = "jdbc-clickhouse"
   retain_ordering       = true
   processing_guarantees = "ATLEAST_ONCE"
-  parallelism           = 3
+  parallelism           = %d
   sink_configs          = jsonencode({
       "userName": "clickhouse",
       "password": "password",
@@ -70,8 +90,10 @@ resource "astra_streaming_sink" "streaming_sink_1" {
       "tableName": "pulsar_clickhouse_jdbc_sink"
   })
   auto_ack              = true
+  # the ClickHouse URL is fake, the sink instances never start
+  wait_for_running      = false
 }
-`, tenantName)
+`, tenantName, parallelism)
 }
 
 func testAccStreamingSnowflakeSinkConfiguration(tenantName string) string {
@@ -156,3 +178,163 @@ func TestSinkIDRegexp(t *testing.T) {
 		t.Errorf("Expected sink ID to NOT match regex, but it did: %s", oldSinkIDExample)
 	}
 }
+
+func TestStreamingSinkConfig(t *testing.T) {
+	retries := int32(3)
+	model := &StreamingSinkResourceModel{
+		TenantName:        types.StringValue("my-tenant"),
+		Namespace:         types.StringValue("default"),
+		SinkName:          types.StringValue("my-sink"),
+		Topic:             types.StringValue("persistent://my-tenant/default/orders"),
+		Inputs:            []string{"persistent://my-tenant/default/refunds"},
+		DeadLetterTopic:   types.StringValue("persistent://my-tenant/default/orders-dlq"),
+		MaxMessageRetries: types.Int32Value(retries),
+		InputSpecs: map[string]pulsarConsumerSpec{
+			"persistent://my-tenant/default/refunds": {SchemaType: astra.StringPtr("AVRO")},
+		},
+	}
+
+	config := model.sinkConfig(map[string]interface{}{})
+	assert.Equal(t, []string{"persistent://my-tenant/default/orders", "persistent://my-tenant/default/refunds"}, *config.Inputs)
+	assert.Equal(t, "persistent://my-tenant/default/orders-dlq", *config.DeadLetterTopic)
+	assert.Equal(t, retries, *config.MaxMessageRetries)
+	assert.Equal(t, "AVRO", *(*config.InputSpecs)["persistent://my-tenant/default/refunds"].SchemaType)
+	assert.Nil(t, config.TopicsPattern)
+	assert.Nil(t, config.Resources)
+	assert.Nil(t, config.Secrets)
+
+	model = &StreamingSinkResourceModel{
+		TopicsPattern: types.StringValue("persistent://my-tenant/default/orders-.*"),
+	}
+	config = model.sinkConfig(map[string]interface{}{})
+	assert.Nil(t, config.Inputs)
+	assert.Equal(t, "persistent://my-tenant/default/orders-.*", *config.TopicsPattern)
+}
+
+func TestSetStreamingSinkDataImported(t *testing.T) {
+	var sink SinkResponse
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"tenant": "my-tenant",
+		"namespace": "default",
+		"name": "my-sink",
+		"archive": "builtin://jdbc-clickhouse",
+		"inputSpecs": {"persistent://my-tenant/default/orders": {"schemaType": "", "regexPattern": false}},
+		"configs": {"jdbcUrl": "jdbc:clickhouse://localhost:8123/db"},
+		"parallelism": 2,
+		"processingGuarantees": "ATLEAST_ONCE",
+		"autoAck": true
+	}`), &sink))
+
+	data := &StreamingSinkResourceModel{
+		Cluster: types.StringValue("pulsar-gcp-useast1"),
+	}
+	setStreamingSinkData(sink, data)
+	assert.Equal(t, "pulsar-gcp-useast1/my-tenant/default/my-sink", data.ID.ValueString())
+	assert.Equal(t, "builtin://jdbc-clickhouse", data.Archive.ValueString())
+	assert.Equal(t, "persistent://my-tenant/default/orders", data.Topic.ValueString())
+	assert.Nil(t, data.Inputs)
+	assert.True(t, data.TopicsPattern.IsNull())
+	assert.JSONEq(t, `{"jdbcUrl": "jdbc:clickhouse://localhost:8123/db"}`, data.SinkConfigs.ValueString())
+
+	assert.Nil(t, data.InputSpecs)
+	assert.True(t, data.SubscriptionName.IsNull())
+	assert.True(t, data.SubscriptionPosition.IsNull())
+	assert.True(t, data.MaxMessageRetries.IsNull())
+	assert.Nil(t, data.Secrets)
+
+	// configured inputs are kept
+	data.Topic = types.StringNull()
+	data.Inputs = []string{"persistent://my-tenant/default/orders"}
+	setStreamingSinkData(sink, data)
+	assert.True(t, data.Topic.IsNull())
+	assert.Equal(t, []string{"persistent://my-tenant/default/orders"}, data.Inputs)
+}
+
+func TestSetStreamingSinkDataImportedSettings(t *testing.T) {
+	var sink SinkResponse
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"tenant": "my-tenant",
+		"namespace": "default",
+		"name": "my-sink",
+		"archive": "builtin://jdbc-clickhouse",
+		"inputs": ["persistent://my-tenant/default/orders", "persistent://my-tenant/default/refunds"],
+		"inputSpecs": {
+			"persistent://my-tenant/default/orders": {"schemaType": "", "regexPattern": false},
+			"persistent://my-tenant/default/refunds": {"schemaType": "AVRO", "receiverQueueSize": 100, "regexPattern": false}
+		},
+		"sourceSubscriptionName": "my-subscription",
+		"sourceSubscriptionPosition": "Earliest",
+		"deadLetterTopic": "persistent://my-tenant/default/dlq",
+		"maxMessageRetries": 3,
+		"negativeAckRedeliveryDelayMs": 500,
+		"timeoutMs": 10000,
+		"secrets": {"password": {"path": "clickhouse", "key": "password"}},
+		"configs": {},
+		"parallelism": 1,
+		"processingGuarantees": "ATLEAST_ONCE"
+	}`), &sink))
+
+	data := &StreamingSinkResourceModel{
+		Cluster: types.StringValue("pulsar-gcp-useast1"),
+	}
+	setStreamingSinkData(sink, data)
+	assert.Equal(t, "persistent://my-tenant/default/orders", data.Topic.ValueString())
+	assert.Equal(t, map[string]pulsarConsumerSpec{
+		"persistent://my-tenant/default/refunds": {SchemaType: astra.StringPtr("AVRO"), ReceiverQueueSize: sink.InputSpecs["persistent://my-tenant/default/refunds"].ReceiverQueueSize},
+	}, data.InputSpecs)
+	assert.Equal(t, "my-subscription", data.SubscriptionName.ValueString())
+	assert.Equal(t, "Earliest", data.SubscriptionPosition.ValueString())
+	assert.Equal(t, "persistent://my-tenant/default/dlq", data.DeadLetterTopic.ValueString())
+	assert.Equal(t, int32(3), data.MaxMessageRetries.ValueInt32())
+	assert.Equal(t, int64(500), data.NegativeAckRedeliveryDelayMs.ValueInt64())
+	assert.Equal(t, int64(10000), data.TimeoutMs.ValueInt64())
+	assert.Equal(t, map[string]pulsarConnectorSecret{
+		"password": {Path: astra.StringPtr("clickhouse"), Key: astra.StringPtr("password")},
+	}, data.Secrets)
+
+	// the settings which aren't configured are not refreshed once imported
+	data.SubscriptionName = types.StringNull()
+	setStreamingSinkData(sink, data)
+	assert.True(t, data.SubscriptionName.IsNull())
+}
+
+func TestRequiresReplaceIfSinkClusterChange(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := fwresource.SchemaResponse{}
+	(&StreamingSinkResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	newState := func(attributes map[string]string) tfsdk.State {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		for name, value := range attributes {
+			values[name] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), values)}
+	}
+	requiresReplace := func(state, plan map[string]string) bool {
+		req := planmodifier.StringRequest{
+			Path:       path.Root("cluster"),
+			State:      newState(state),
+			Plan:       tfsdk.Plan(newState(plan)),
+			StateValue: types.StringValue("state"),
+			PlanValue:  types.StringValue("plan"),
+		}
+		resp := &planmodifier.StringResponse{}
+		requiresReplaceIfSinkClusterChange().PlanModifyString(ctx, req, resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		return resp.RequiresReplace
+	}
+
+	// an imported sink configured with the cloud provider and region
+	imported := map[string]string{"id": "pulsar-gcp-useast1/my-tenant/default/my-sink", "cluster": "pulsar-gcp-useast1"}
+	assert.False(t, requiresReplace(imported, map[string]string{"cloud_provider": "gcp", "region": "us-east1"}))
+	assert.True(t, requiresReplace(imported, map[string]string{"cloud_provider": "gcp", "region": "us-central1"}))
+	assert.False(t, requiresReplace(imported, map[string]string{"pulsar_cluster": "pulsar-gcp-useast1"}))
+	assert.True(t, requiresReplace(imported, map[string]string{"cluster": "pulsar-gcp-uscentral1"}))
+
+	// a sink created with the cloud provider and region
+	created := map[string]string{"id": "pulsar-gcp-useast1/my-tenant/default/my-sink", "cloud_provider": "gcp", "region": "us-east1"}
+	assert.False(t, requiresReplace(created, map[string]string{"cluster": "pulsar-gcp-useast1"}))
+	assert.True(t, requiresReplace(created, map[string]string{"cloud_provider": "aws", "region": "us-east1"}))
+}
@@ -0,0 +1,553 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &StreamingSourceResource{}
+	_ resource.ResourceWithConfigure   = &StreamingSourceResource{}
+	_ resource.ResourceWithModifyPlan  = &StreamingSourceResource{}
+	_ resource.ResourceWithImportState = &StreamingSourceResource{}
+)
+
+func NewStreamingSourceResource() resource.Resource {
+	return &StreamingSourceResource{}
+}
+
+// StreamingSourceResource is a Pulsar IO source, which reads data from an external system and writes it to a topic.
+type StreamingSourceResource struct {
+	clients *astraClients2
+}
+
+type StreamingSourceResourceModel struct {
+	ID                   types.String                     `tfsdk:"id"`
+	Cluster              types.String                     `tfsdk:"cluster"`
+	TenantName           types.String                     `tfsdk:"tenant_name"`
+	Namespace            types.String                     `tfsdk:"namespace"`
+	SourceName           types.String                     `tfsdk:"source_name"`
+	Archive              types.String                     `tfsdk:"archive"`
+	Topic                types.String                     `tfsdk:"topic"`
+	SchemaType           types.String                     `tfsdk:"schema_type"`
+	SerdeClassName       types.String                     `tfsdk:"serde_class_name"`
+	SourceConfigs        types.String                     `tfsdk:"source_configs"`
+	Secrets              map[string]pulsarConnectorSecret `tfsdk:"secrets"`
+	Parallelism          types.Int32                      `tfsdk:"parallelism"`
+	ProcessingGuarantees types.String                     `tfsdk:"processing_guarantees"`
+	Resources            *pulsarConnectorResources        `tfsdk:"resources"`
+	DeletionProtection   types.Bool                       `tfsdk:"deletion_protection"`
+	WaitForRunning       types.Bool                       `tfsdk:"wait_for_running"`
+	Timeouts             timeouts.Value                   `tfsdk:"timeouts"`
+	pulsarConnectorStatusModel
+}
+
+// streamingSourceConfig is the configuration of a Pulsar source. The generated client doesn't model the output topic
+// and the schema of a source, so this is sent as the raw body of the requests.
+type streamingSourceConfig struct {
+	Tenant               string                             `json:"tenant"`
+	Namespace            string                             `json:"namespace"`
+	Name                 string                             `json:"name"`
+	Archive              string                             `json:"archive,omitempty"`
+	TopicName            string                             `json:"topicName"`
+	SchemaType           *string                            `json:"schemaType,omitempty"`
+	SerdeClassName       *string                            `json:"serdeClassName,omitempty"`
+	Configs              map[string]interface{}             `json:"configs,omitempty"`
+	Secrets              *map[string]map[string]interface{} `json:"secrets,omitempty"`
+	Parallelism          *int32                             `json:"parallelism,omitempty"`
+	ProcessingGuarantees *string                            `json:"processingGuarantees,omitempty"`
+	Resources            *astrastreaming.Resources          `json:"resources,omitempty"`
+}
+
+func (r *StreamingSourceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_streaming_source"
+}
+
+func (r *StreamingSourceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a streaming source which reads data from an external system and writes it to a topic.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique ID in the form cluster_name/tenant_name/namespace/source_name",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				Description: "Name of the pulsar cluster in which to create the source.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 32),
+				},
+			},
+			"tenant_name": schema.StringAttribute{
+				Description: "Streaming tenant name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 64),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description: "Pulsar Namespace",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(2, 64),
+				},
+			},
+			"source_name": schema.StringAttribute{
+				Description: "Name of the source. Note that the combination of tenant, namespace, and source name must not exceed 47 characters.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"archive": schema.StringAttribute{
+				Description: "Name of the source archive type to use, formatted as a URL, e.g. 'builtin://kafka' or 'builtin://debezium-postgres'.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"topic": schema.StringAttribute{
+				Description: "Full name of the topic which the source writes to, e.g. 'persistent://my-tenant/my-namespace/my-topic'.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema_type": schema.StringAttribute{
+				Description: "Schema type of the output topic, for example \"AVRO\", \"JSON\" or \"STRING\".",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"serde_class_name": schema.StringAttribute{
+				Description: "Class name of the SerDe used to serialize the messages written to the output topic.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_configs": schema.StringAttribute{
+				Description: "Source Configs, as a JSON string. Changing the configs updates the source in place, formatting and key order are ignored.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					planModifierSuppressJSONDiff(),
+				},
+			},
+			"secrets": pulsarConnectorSecretsSchema(),
+			"parallelism": schema.Int32Attribute{
+				Description: "Parallelism for Pulsar source. Changing the parallelism updates the source in place.",
+				Required:    true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"processing_guarantees": schema.StringAttribute{
+				Description: "\"ATLEAST_ONCE\" \"ATMOST_ONCE\" \"EFFECTIVELY_ONCE\". Defaults to \"ATLEAST_ONCE\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("ATLEAST_ONCE"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("ATLEAST_ONCE", "ATMOST_ONCE", "EFFECTIVELY_ONCE"),
+				},
+			},
+			"resources": pulsarConnectorResourcesSchema(),
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether or not to allow Terraform to destroy this streaming source. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the instance will fail. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"wait_for_running": schema.BoolAttribute{
+				Description: "Whether to wait for all the instances of the source to be running after it is created or updated. " +
+					"The create or update fails if the instances are not running before the timeout. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+	for name, attribute := range pulsarConnectorStatusAttributes("source") {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (r *StreamingSourceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+// ModifyPlan validates the source archive and configs against the connectors of the cluster.
+func (r *StreamingSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.clients == nil {
+		// resource is being destroyed, or the provider is not configured yet
+		return
+	}
+
+	var cluster, archive, sourceConfigs types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cluster"), &cluster)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("archive"), &archive)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source_configs"), &sourceConfigs)...)
+	if resp.Diagnostics.HasError() || cluster.IsUnknown() || archive.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var stateArchive, stateSourceConfigs types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("archive"), &stateArchive)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source_configs"), &stateSourceConfigs)...)
+		if resp.Diagnostics.HasError() || (archive.Equal(stateArchive) && sourceConfigs.Equal(stateSourceConfigs)) {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(validateBuiltinConnector(ctx, r.clients.astraStreamingClient, cluster.ValueString(), "sources", archive.ValueString(), sourceConfigs, path.Root("source_configs"))...)
+}
+
+func (r *StreamingSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := &StreamingSourceResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultConnectorRunningTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID, err := getCurrentOrgID(ctx, r.clients.astraClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to get current OrgID",
+			err.Error())
+		return
+	}
+
+	body, err := plan.sourceConfigBody()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"invalid source config",
+			err.Error())
+		return
+	}
+
+	createSourceParams := astrastreaming.CreateSourceJSONParams{
+		XDataStaxPulsarCluster: plan.Cluster.ValueString(),
+		XDataStaxCurrentOrg:    orgID,
+		Authorization:          r.clients.token,
+	}
+	sourceCreationResponse, err := r.clients.astraStreamingClient.CreateSourceJSONWithBody(ctx, plan.TenantName.ValueString(),
+		plan.Namespace.ValueString(), plan.SourceName.ValueString(), &createSourceParams, "application/json", bytes.NewReader(body))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to create source",
+			err.Error())
+		return
+	} else if sourceCreationResponse.StatusCode > 299 {
+		respBody, _ := io.ReadAll(sourceCreationResponse.Body)
+		resp.Diagnostics.AddError(
+			"failed to create source",
+			fmt.Sprintf("failed to create source, status code: %d, message: %s", sourceCreationResponse.StatusCode, string(respBody)))
+		return
+	}
+
+	plan.setID()
+	// the source is saved even if it is not running, so that it is tainted rather than lost
+	err = r.refreshSourceStatus(ctx, plan, plan.WaitForRunning.ValueBool(), nil, createTimeout, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"source is not running after create",
+			err.Error())
+	}
+}
+
+func (r *StreamingSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := &StreamingSourceResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := state.Cluster.ValueString()
+	tenantName := state.TenantName.ValueString()
+	namespace := state.Namespace.ValueString()
+	sourceName := state.SourceName.ValueString()
+
+	body, statusCode, err := streamingRequest(ctx, r.clients.astraStreamingClient, cluster, http.MethodGet, sourcePath(tenantName, namespace, sourceName), nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to get source",
+			err.Error())
+		return
+	} else if statusCode == http.StatusNotFound {
+		// source not found, remove it from the state
+		resp.State.RemoveResource(ctx)
+		return
+	} else if statusCode > 299 {
+		resp.Diagnostics.AddError(
+			"failed to get source",
+			fmt.Sprintf("failed to get source, status code: %d, message: %s", statusCode, string(body)))
+		return
+	}
+
+	var source streamingSourceConfig
+	if err := json.Unmarshal(body, &source); err != nil {
+		resp.Diagnostics.AddError(
+			"failed to unmarshal source response",
+			err.Error())
+		return
+	}
+	setStreamingSourceData(source, state)
+
+	status, err := getPulsarConnectorStatus(ctx, r.clients.astraStreamingClient, cluster, "sources", tenantName, namespace, sourceName)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("failed to get source status: %v", err))
+	} else {
+		resp.Diagnostics.Append(state.setStatus(ctx, status)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the source in place when its configuration changed, and waits for its instances to be running again.
+func (r *StreamingSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := &StreamingSourceResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	state := &StreamingSourceResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultConnectorRunningTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated := plan.requiresSourceUpdate(state)
+	var previousStatus *pulsarConnectorStatus
+	if updated {
+		if plan.WaitForRunning.ValueBool() {
+			// the instances of the previous deployment are still reported as running right after the update
+			previousStatus, _ = getPulsarConnectorStatus(ctx, r.clients.astraStreamingClient, state.Cluster.ValueString(), "sources",
+				state.TenantName.ValueString(), state.Namespace.ValueString(), state.SourceName.ValueString())
+		}
+
+		orgID, err := getCurrentOrgID(ctx, r.clients.astraClient)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"failed to get current OrgID",
+				err.Error())
+			return
+		}
+
+		body, err := plan.sourceConfigBody()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"invalid source config",
+				err.Error())
+			return
+		}
+
+		updateSourceParams := astrastreaming.UpdateSourceJSONParams{
+			XDataStaxPulsarCluster: state.Cluster.ValueString(),
+			XDataStaxCurrentOrg:    orgID,
+			Authorization:          r.clients.token,
+		}
+		sourceUpdateResponse, err := r.clients.astraStreamingClient.UpdateSourceJSONWithBody(ctx, state.TenantName.ValueString(),
+			state.Namespace.ValueString(), state.SourceName.ValueString(), &updateSourceParams, "application/json", bytes.NewReader(body))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"failed to update source",
+				err.Error())
+			return
+		} else if sourceUpdateResponse.StatusCode > 299 {
+			respBody, _ := io.ReadAll(sourceUpdateResponse.Body)
+			resp.Diagnostics.AddError(
+				"failed to update source",
+				fmt.Sprintf("failed to update source, status code: %d, message: %s", sourceUpdateResponse.StatusCode, string(respBody)))
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	err := r.refreshSourceStatus(ctx, plan, updated && plan.WaitForRunning.ValueBool(), previousStatus, updateTimeout, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"source is not running after update",
+			err.Error())
+	}
+}
+
+func (r *StreamingSourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := &StreamingSourceResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"failed to delete source",
+			"deletion protection is enabled. Set `deletion_protection` to `false` to allow deletion of the source resource.")
+		return
+	}
+
+	body, statusCode, err := streamingRequest(ctx, r.clients.astraStreamingClient, state.Cluster.ValueString(), http.MethodDelete,
+		sourcePath(state.TenantName.ValueString(), state.Namespace.ValueString(), state.SourceName.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to delete source",
+			err.Error())
+		return
+	} else if statusCode > 299 && statusCode != http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"failed to delete source",
+			fmt.Sprintf("failed to delete source, status code: %d, message: %s", statusCode, string(body)))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState reads the ID from the CLI and then calls Read() to get the state of the source
+func (r *StreamingSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sourceID := strings.Split(req.ID, "/")
+	if len(sourceID) != 4 {
+		resp.Diagnostics.AddError(
+			"Error importing source",
+			"ID must be in the format <cluster>/<tenant>/<namespace>/<source_name>",
+		)
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), sourceID[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_name"), sourceID[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), sourceID[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_name"), sourceID[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_running"), true)...)
+}
+
+// refreshSourceStatus sets the status attributes of the model from the current status of the source, see refreshPulsarConnectorStatus.
+func (r *StreamingSourceResource) refreshSourceStatus(ctx context.Context, m *StreamingSourceResourceModel, wait bool, previous *pulsarConnectorStatus, timeout time.Duration, diags *diag.Diagnostics) error {
+	status, err := refreshPulsarConnectorStatus(ctx, r.clients.astraStreamingClient, m.Cluster.ValueString(), "sources",
+		m.TenantName.ValueString(), m.Namespace.ValueString(), m.SourceName.ValueString(), wait, int(m.Parallelism.ValueInt32()), previous, timeout)
+	diags.Append(m.setStatus(ctx, status)...)
+	return err
+}
+
+// sourcePath returns the path of a source in the Astra Streaming API.
+func sourcePath(tenant, namespace, name string) string {
+	return fmt.Sprintf("admin/v3/astrasources/%s/%s/%s", tenant, namespace, name)
+}
+
+// sourceConfigBody returns the JSON configuration of the source.
+func (m *StreamingSourceResourceModel) sourceConfigBody() ([]byte, error) {
+	var configs map[string]interface{}
+	if err := json.Unmarshal([]byte(m.SourceConfigs.ValueString()), &configs); err != nil {
+		return nil, err
+	}
+	return json.Marshal(streamingSourceConfig{
+		Tenant:               m.TenantName.ValueString(),
+		Namespace:            m.Namespace.ValueString(),
+		Name:                 m.SourceName.ValueString(),
+		Archive:              m.Archive.ValueString(),
+		TopicName:            m.Topic.ValueString(),
+		SchemaType:           m.SchemaType.ValueStringPointer(),
+		SerdeClassName:       m.SerdeClassName.ValueStringPointer(),
+		Configs:              configs,
+		Secrets:              pulsarConnectorSecrets(m.Secrets),
+		Parallelism:          m.Parallelism.ValueInt32Pointer(),
+		ProcessingGuarantees: m.ProcessingGuarantees.ValueStringPointer(),
+		Resources:            m.Resources.apiResources(),
+	})
+}
+
+// requiresSourceUpdate returns true if any of the attributes which can be updated in place changed.
+func (m *StreamingSourceResourceModel) requiresSourceUpdate(state *StreamingSourceResourceModel) bool {
+	return !m.Parallelism.Equal(state.Parallelism) ||
+		!m.SourceConfigs.Equal(state.SourceConfigs) ||
+		!reflect.DeepEqual(m.Resources, state.Resources) ||
+		!reflect.DeepEqual(m.Secrets, state.Secrets)
+}
+
+func (m *StreamingSourceResourceModel) setID() {
+	m.ID = types.StringValue(strings.Join([]string{m.Cluster.ValueString(), m.TenantName.ValueString(), m.Namespace.ValueString(), m.SourceName.ValueString()}, "/"))
+}
+
+// setStreamingSourceData copies the data from the REST API endpoint response to the Terraform resource model.
+func setStreamingSourceData(source streamingSourceConfig, data *StreamingSourceResourceModel) {
+	data.Archive = types.StringValue(source.Archive)
+	data.Topic = types.StringValue(source.TopicName)
+	if source.SchemaType != nil && *source.SchemaType != "" {
+		data.SchemaType = types.StringPointerValue(source.SchemaType)
+	}
+	if source.SerdeClassName != nil && *source.SerdeClassName != "" {
+		data.SerdeClassName = types.StringPointerValue(source.SerdeClassName)
+	}
+	if source.Parallelism != nil {
+		data.Parallelism = types.Int32PointerValue(source.Parallelism)
+	}
+	if source.ProcessingGuarantees != nil {
+		data.ProcessingGuarantees = types.StringPointerValue(source.ProcessingGuarantees)
+	}
+	if jsonConfigs, err := json.Marshal(source.Configs); err == nil && !jsonEquivalent(data.SourceConfigs.ValueString(), string(jsonConfigs)) {
+		// keep the configured formatting when the configs didn't change
+		data.SourceConfigs = types.StringValue(string(jsonConfigs))
+	}
+	data.Resources = refreshPulsarConnectorResources(data.Resources, source.Resources)
+	data.setID()
+}
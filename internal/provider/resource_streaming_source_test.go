@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccStreamingSource(t *testing.T) {
+	// Disable this test by default until test works with non-prod clusters
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_SOURCE_TEST_ENABLED")
+
+	tenantName := fmt.Sprintf("terraform-test-%s", uuid.New().String())[0:20]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingSourceConfiguration(tenantName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_source.streaming_source_1", "num_running", "1"),
+				),
+			},
+			{
+				// parallelism is updated in place
+				Config: testAccStreamingSourceConfiguration(tenantName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_source.streaming_source_1", "num_running", "2"),
+				),
+			},
+			{
+				ResourceName:            "astra_streaming_source.streaming_source_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "source_configs", "num_restarts", "instances", "latest_error"},
+			},
+		},
+	})
+}
+
+func testAccStreamingSourceConfiguration(tenantName string, parallelism int) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_tenant" "streaming_tenant_1" {
+  deletion_protection = false
+  tenant_name         = "%s"
+  topic               = "terraformtest"
+  cloud_provider      = "gcp"
+  region              = "us-east4"
+  user_email          = "test@datastax.com"
+}
+
+resource "astra_streaming_topic" "streaming_topic_1" {
+  deletion_protection   = false
+  cluster               = astra_streaming_tenant.streaming_tenant_1.cluster_name
+  tenant                = astra_streaming_tenant.streaming_tenant_1.tenant_name
+  namespace             = "default"
+  topic                 = "terraform-source-test-1"
+}
+
+resource "astra_streaming_source" "streaming_source_1" {
+  deletion_protection   = false
+  cluster               = astra_streaming_tenant.streaming_tenant_1.cluster_name
+  tenant_name           = astra_streaming_tenant.streaming_tenant_1.tenant_name
+  namespace             = "default"
+  source_name           = "data-generator"
+  archive               = "builtin://data-generator"
+  topic                 = astra_streaming_topic.streaming_topic_1.topic_fqn
+  parallelism           = %d
+  source_configs        = jsonencode({
+    "sleepBetweenMessages": "1000"
+  })
+}
+`, tenantName, parallelism)
+}
+
+func TestStreamingSourceConfigBody(t *testing.T) {
+	model := &StreamingSourceResourceModel{
+		TenantName:           types.StringValue("my-tenant"),
+		Namespace:            types.StringValue("default"),
+		SourceName:           types.StringValue("my-source"),
+		Archive:              types.StringValue("builtin://kafka"),
+		Topic:                types.StringValue("persistent://my-tenant/default/orders"),
+		SchemaType:           types.StringValue("STRING"),
+		SourceConfigs:        types.StringValue(`{"bootstrapServers": "kafka:9092"}`),
+		Parallelism:          types.Int32Value(2),
+		ProcessingGuarantees: types.StringValue("ATLEAST_ONCE"),
+	}
+	body, err := model.sourceConfigBody()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"tenant": "my-tenant",
+		"namespace": "default",
+		"name": "my-source",
+		"archive": "builtin://kafka",
+		"topicName": "persistent://my-tenant/default/orders",
+		"schemaType": "STRING",
+		"configs": {"bootstrapServers": "kafka:9092"},
+		"parallelism": 2,
+		"processingGuarantees": "ATLEAST_ONCE"
+	}`, string(body))
+
+	model.SourceConfigs = types.StringValue("not json")
+	_, err = model.sourceConfigBody()
+	assert.NotNil(t, err)
+}
+
+func TestSetStreamingSourceData(t *testing.T) {
+	var source streamingSourceConfig
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"tenant": "my-tenant",
+		"namespace": "default",
+		"name": "my-source",
+		"archive": "builtin://kafka",
+		"topicName": "persistent://my-tenant/default/orders",
+		"schemaType": "",
+		"configs": {"groupId": "astra", "bootstrapServers": "kafka:9092"},
+		"parallelism": 3,
+		"processingGuarantees": "ATLEAST_ONCE",
+		"resources": {"cpu": 0.25, "ram": 1073741824, "disk": 10737418240}
+	}`), &source))
+
+	data := &StreamingSourceResourceModel{
+		Cluster:       types.StringValue("pulsar-gcp-useast1"),
+		TenantName:    types.StringValue("my-tenant"),
+		Namespace:     types.StringValue("default"),
+		SourceName:    types.StringValue("my-source"),
+		SourceConfigs: types.StringValue(`{"bootstrapServers": "kafka:9092", "groupId": "astra"}`),
+	}
+	setStreamingSourceData(source, data)
+	assert.Equal(t, "pulsar-gcp-useast1/my-tenant/default/my-source", data.ID.ValueString())
+	assert.Equal(t, "persistent://my-tenant/default/orders", data.Topic.ValueString())
+	assert.True(t, data.SchemaType.IsNull())
+	assert.Equal(t, int32(3), data.Parallelism.ValueInt32())
+	// the configured formatting is kept
+	assert.Equal(t, `{"bootstrapServers": "kafka:9092", "groupId": "astra"}`, data.SourceConfigs.ValueString())
+	assert.Nil(t, data.Resources)
+}
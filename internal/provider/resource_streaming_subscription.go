@@ -0,0 +1,437 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datastax/pulsar-admin-client-go/src/pulsaradmin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &StreamingSubscriptionResource{}
+	_ resource.ResourceWithConfigure   = &StreamingSubscriptionResource{}
+	_ resource.ResourceWithImportState = &StreamingSubscriptionResource{}
+)
+
+const (
+	subscriptionPositionEarliest = "earliest"
+	subscriptionPositionLatest   = "latest"
+)
+
+var (
+	// subscriptionMessageIDRegex matches a message ID in the format "<ledger_id>:<entry_id>"
+	subscriptionMessageIDRegex = regexp.MustCompile(`^([0-9]+):([0-9]+)$`)
+	// subscriptionTimestampRegex matches an RFC 3339 timestamp, e.g. "2024-01-02T15:04:05Z"
+	subscriptionTimestampRegex = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?(Z|[+-][0-9]{2}:[0-9]{2})$`)
+	// subscriptionTopicRegex matches the fully qualified name of a persistent topic
+	subscriptionTopicRegex = regexp.MustCompile(`^persistent://([A-Za-z][\w-.]*)/([A-Za-z][\w-.]*)/([A-Za-z][\w-.]*)$`)
+	// subscriptionIDRegex matches a subscription ID in the format "cluster-name/tenant-name/namespace/topic/subscription-name"
+	subscriptionIDRegex = regexp.MustCompile(`^[A-Za-z][\w-.]+\/[A-Za-z][\w-.]+\/[A-Za-z][\w-.]+\/[A-Za-z][\w-.]+\/.+$`)
+)
+
+// NewStreamingSubscriptionResource is a helper function to simplify the provider implementation.
+func NewStreamingSubscriptionResource() resource.Resource {
+	return &StreamingSubscriptionResource{}
+}
+
+// StreamingSubscriptionResource is the resource implementation.
+type StreamingSubscriptionResource struct {
+	clients *astraClients2
+}
+
+// StreamingSubscriptionResourceModel maps the resource schema data.
+type StreamingSubscriptionResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Cluster            types.String `tfsdk:"cluster"`
+	TopicFQN           types.String `tfsdk:"topic_fqn"`
+	SubscriptionName   types.String `tfsdk:"subscription_name"`
+	InitialPosition    types.String `tfsdk:"initial_position"`
+	Replicated         types.Bool   `tfsdk:"replicated"`
+	ResetTo            types.String `tfsdk:"reset_to"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Metadata returns the resource type name.
+func (r *StreamingSubscriptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_streaming_subscription"
+}
+
+// Schema defines the schema for the resource.
+func (r *StreamingSubscriptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A durable subscription to a persistent Pulsar topic.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the subscription, in the format <cluster>/<tenant>/<namespace>/<topic>/<subscription_name>",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				Description: "Name of the Pulsar cluster where the topic is located.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"topic_fqn": schema.StringAttribute{
+				Description: "Fully qualified name of the persistent topic, for example 'persistent://mytenant/namespace1/mytopic'",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(subscriptionTopicRegex, "must be the fully qualified name of a persistent topic"),
+				},
+			},
+			"subscription_name": schema.StringAttribute{
+				Description: "Name of the subscription.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"initial_position": schema.StringAttribute{
+				Description: "Position of the cursor when the subscription is created, either 'earliest', 'latest' or a message ID " +
+					"in the format '<ledger_id>:<entry_id>'. Defaults to 'latest'.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(subscriptionPositionLatest),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(requiresReplaceIfCreatedWithPosition,
+						"If the initial position changes, Terraform will destroy and recreate the resource.",
+						"If the initial position changes, Terraform will destroy and recreate the resource.",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.OneOf(subscriptionPositionEarliest, subscriptionPositionLatest),
+						stringvalidator.RegexMatches(subscriptionMessageIDRegex, "must be a message ID in the format '<ledger_id>:<entry_id>'"),
+					),
+				},
+			},
+			"replicated": schema.BoolAttribute{
+				Description: "Replicate the subscription state across geo-replicated clusters.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"reset_to": schema.StringAttribute{
+				Description: "Resets the cursor of the subscription when this value changes. Either an RFC 3339 timestamp, " +
+					"for example '2024-01-02T15:04:05Z', or a message ID in the format '<ledger_id>:<entry_id>'.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.RegexMatches(subscriptionTimestampRegex, "must be an RFC 3339 timestamp"),
+						stringvalidator.RegexMatches(subscriptionMessageIDRegex, "must be a message ID in the format '<ledger_id>:<entry_id>'"),
+					),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Prevent this subscription from being deleted via Terraform",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *StreamingSubscriptionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(*astraClients2)
+}
+
+// requiresReplaceIfCreatedWithPosition requires the replacement of the subscription if its initial position changes.
+// Imported subscriptions have no initial position, so setting it only updates the state.
+func requiresReplaceIfCreatedWithPosition(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull()
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *StreamingSubscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan StreamingSubscriptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant, namespace, topic := plan.topicPath()
+	subscription := plan.SubscriptionName.ValueString()
+	pulsarClient := r.clients.pulsarAdminClient
+	pulsarRequestEditor := setPulsarClusterHeaders(plan.Cluster.ValueString())
+
+	position, err := subscriptionInitialPosition(plan.InitialPosition.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("initial_position"), "invalid subscription initial position", err.Error())
+		return
+	}
+	positionBody, err := json.Marshal(position)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to encode subscription initial position", err.Error())
+		return
+	}
+	params := pulsaradmin.PersistentTopicsCreateSubscriptionParams{
+		Replicated: plan.Replicated.ValueBoolPointer(),
+	}
+	httpResp, err := pulsarClient.PersistentTopicsCreateSubscriptionWithBody(ctx, tenant, namespace, topic, subscription, &params,
+		"application/json", bytes.NewReader(positionBody), pulsarRequestEditor)
+	resp.Diagnostics.Append(HTTPResponseDiagErr(httpResp, err, "failed to create subscription")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(plan.generateID())
+
+	if !plan.ResetTo.IsNull() {
+		resp.Diagnostics.Append(resetSubscriptionCursor(ctx, pulsarClient, tenant, namespace, topic, subscription, plan.ResetTo.ValueString(), pulsarRequestEditor)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *StreamingSubscriptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state StreamingSubscriptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant, namespace, topic := state.topicPath()
+	subscription := state.SubscriptionName.ValueString()
+	pulsarClient := r.clients.pulsarAdminClient
+	pulsarRequestEditor := setPulsarClusterHeaders(state.Cluster.ValueString())
+
+	subscriptionsResp, err := pulsarClient.PersistentTopicsGetSubscriptionsWithResponse(ctx, tenant, namespace, topic,
+		&pulsaradmin.PersistentTopicsGetSubscriptionsParams{}, pulsarRequestEditor)
+	if err == nil && (subscriptionsResp.StatusCode() == 404 || subscriptionsResp.StatusCode() == 401) {
+		// we get back a 401 if the tenant is not present
+		// we get back a 404 if the topic is not present
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get subscriptions", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(HTTPResponseDiagErrWithBody(subscriptionsResp.StatusCode(), subscriptionsResp.Body, err, "failed to get subscriptions")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if subscriptionsResp.JSON200 == nil || !slices.Contains(*subscriptionsResp.JSON200, subscription) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	replicatedResp, err := pulsarClient.PersistentTopicsGetReplicatedSubscriptionStatusWithResponse(ctx, tenant, namespace, topic, subscription,
+		&pulsaradmin.PersistentTopicsGetReplicatedSubscriptionStatusParams{}, pulsarRequestEditor)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to get subscription replication status", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(HTTPResponseDiagErrWithBody(replicatedResp.StatusCode(), replicatedResp.Body, err, "failed to get subscription replication status")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if replicatedResp.JSON200 != nil {
+		state.Replicated = types.BoolValue(subscriptionReplicated(*replicatedResp.JSON200))
+	}
+
+	state.ID = types.StringValue(state.generateID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the replication of the subscription and resets its cursor when `reset_to` changes.
+func (r *StreamingSubscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state StreamingSubscriptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenant, namespace, topic := plan.topicPath()
+	subscription := plan.SubscriptionName.ValueString()
+	pulsarClient := r.clients.pulsarAdminClient
+	pulsarRequestEditor := setPulsarClusterHeaders(plan.Cluster.ValueString())
+
+	if !plan.Replicated.Equal(state.Replicated) {
+		httpResp, err := pulsarClient.PersistentTopicsSetReplicatedSubscriptionStatusWithBody(ctx, tenant, namespace, topic, subscription,
+			&pulsaradmin.PersistentTopicsSetReplicatedSubscriptionStatusParams{}, "application/json",
+			strings.NewReader(strconv.FormatBool(plan.Replicated.ValueBool())), pulsarRequestEditor)
+		resp.Diagnostics.Append(HTTPResponseDiagErr(httpResp, err, "failed to update subscription replication")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !plan.ResetTo.IsNull() && !plan.ResetTo.Equal(state.ResetTo) {
+		resp.Diagnostics.Append(resetSubscriptionCursor(ctx, pulsarClient, tenant, namespace, topic, subscription, plan.ResetTo.ValueString(), pulsarRequestEditor)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(plan.generateID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *StreamingSubscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state StreamingSubscriptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Attempted to delete protected subscription: "+state.ID.ValueString(),
+			"Subscription field 'deletion_protection' must be set to 'false' to allow this subscription to be deleted",
+		)
+		return
+	}
+
+	tenant, namespace, topic := state.topicPath()
+	pulsarRequestEditor := setPulsarClusterHeaders(state.Cluster.ValueString())
+	httpResp, err := r.clients.pulsarAdminClient.PersistentTopicsDeleteSubscription(ctx, tenant, namespace, topic, state.SubscriptionName.ValueString(),
+		&pulsaradmin.PersistentTopicsDeleteSubscriptionParams{}, pulsarRequestEditor)
+	if err == nil && httpResp.StatusCode == 404 {
+		// the subscription or its topic was already deleted
+		return
+	}
+	resp.Diagnostics.Append(HTTPResponseDiagErr(httpResp, err, "failed to delete subscription")...)
+}
+
+// ImportState sets the subscription attributes from an ID in the format <cluster>/<tenant>/<namespace>/<topic>/<subscription_name>
+func (r *StreamingSubscriptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !subscriptionIDRegex.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Error importing subscription",
+			"ID must be in the format <cluster>/<tenant>/<namespace>/<topic>/<subscription_name>",
+		)
+		return
+	}
+	subscriptionID := strings.SplitN(req.ID, "/", 5)
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), subscriptionID[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("topic_fqn"),
+		fmt.Sprintf("persistent://%s/%s/%s", subscriptionID[1], subscriptionID[2], subscriptionID[3]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subscription_name"), subscriptionID[4])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+}
+
+// topicPath returns the tenant, namespace and name of the topic of the subscription
+func (m *StreamingSubscriptionResourceModel) topicPath() (string, string, string) {
+	parts := subscriptionTopicRegex.FindStringSubmatch(m.TopicFQN.ValueString())
+	if len(parts) != 4 {
+		return "", "", ""
+	}
+	return parts[1], parts[2], parts[3]
+}
+
+func (m *StreamingSubscriptionResourceModel) generateID() string {
+	tenant, namespace, topic := m.topicPath()
+	return fmt.Sprintf("%s/%s/%s/%s/%s", m.Cluster.ValueString(), tenant, namespace, topic, m.SubscriptionName.ValueString())
+}
+
+// subscriptionInitialPosition returns the message ID where the cursor of a new subscription is positioned
+func subscriptionInitialPosition(position string) (*pulsaradmin.ResetCursorData, error) {
+	var ledgerID, entryID int64
+	partitionIndex := int32(-1)
+	switch position {
+	case subscriptionPositionEarliest:
+		ledgerID, entryID = -1, -1
+	case subscriptionPositionLatest:
+		ledgerID, entryID = math.MaxInt64, math.MaxInt64
+	default:
+		return parseSubscriptionMessageID(position)
+	}
+	return &pulsaradmin.ResetCursorData{LedgerId: &ledgerID, EntryId: &entryID, PartitionIndex: &partitionIndex}, nil
+}
+
+// parseSubscriptionMessageID parses a message ID in the format "<ledger_id>:<entry_id>"
+func parseSubscriptionMessageID(messageID string) (*pulsaradmin.ResetCursorData, error) {
+	parts := subscriptionMessageIDRegex.FindStringSubmatch(messageID)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid message ID '%s', expected the format '<ledger_id>:<entry_id>'", messageID)
+	}
+	ledgerID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ledger ID in message ID '%s': %w", messageID, err)
+	}
+	entryID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry ID in message ID '%s': %w", messageID, err)
+	}
+	return &pulsaradmin.ResetCursorData{LedgerId: &ledgerID, EntryId: &entryID}, nil
+}
+
+// resetSubscriptionCursor resets the cursor of a subscription to a timestamp or a message ID
+func resetSubscriptionCursor(ctx context.Context, client *pulsaradmin.ClientWithResponses, tenant, namespace, topic, subscription, resetTo string,
+	requestEditors ...pulsaradmin.RequestEditorFn) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if subscriptionTimestampRegex.MatchString(resetTo) {
+		timestamp, err := time.Parse(time.RFC3339, resetTo)
+		if err != nil {
+			diags.AddAttributeError(path.Root("reset_to"), "invalid subscription reset timestamp", err.Error())
+			return diags
+		}
+		httpResp, err := client.PersistentTopicsResetCursor(ctx, tenant, namespace, topic, subscription, timestamp.UnixMilli(),
+			&pulsaradmin.PersistentTopicsResetCursorParams{}, requestEditors...)
+		diags.Append(HTTPResponseDiagErr(httpResp, err, "failed to reset subscription cursor")...)
+		return diags
+	}
+
+	messageID, err := parseSubscriptionMessageID(resetTo)
+	if err != nil {
+		diags.AddAttributeError(path.Root("reset_to"), "invalid subscription reset position", err.Error())
+		return diags
+	}
+	messageIDBody, err := json.Marshal(messageID)
+	if err != nil {
+		diags.AddError("failed to encode subscription reset position", err.Error())
+		return diags
+	}
+	httpResp, err := client.PersistentTopicsResetCursorOnPositionWithBody(ctx, tenant, namespace, topic, subscription,
+		&pulsaradmin.PersistentTopicsResetCursorOnPositionParams{}, "application/json", bytes.NewReader(messageIDBody), requestEditors...)
+	diags.Append(HTTPResponseDiagErr(httpResp, err, "failed to reset subscription cursor")...)
+	return diags
+}
+
+// subscriptionReplicated returns true if the subscription is replicated on all the partitions of its topic
+func subscriptionReplicated(status map[string]bool) bool {
+	if len(status) == 0 {
+		return false
+	}
+	for _, replicated := range status {
+		if !replicated {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccStreamingSubscription(t *testing.T) {
+	// Disable this test by default until test works with non-prod clusters
+	checkRequiredTestVars(t, "ASTRA_TEST_STREAMING_SUBSCRIPTION_TEST_ENABLED")
+
+	tenantName := fmt.Sprintf("terraform-test-%s", uuid.New().String())[0:20]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamingSubscriptionConfiguration(tenantName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_subscription.subscription_1", "subscription_name", "terraform-test"),
+					resource.TestCheckResourceAttr("astra_streaming_subscription.subscription_1", "replicated", "false"),
+				),
+			},
+			{
+				// the cursor is reset in place
+				Config: testAccStreamingSubscriptionConfiguration(tenantName, `reset_to = "2024-01-02T15:04:05Z"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("astra_streaming_subscription.subscription_1", "reset_to", "2024-01-02T15:04:05Z"),
+				),
+			},
+			{
+				ResourceName:            "astra_streaming_subscription.subscription_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "initial_position", "reset_to"},
+			},
+		},
+	})
+}
+
+func testAccStreamingSubscriptionConfiguration(tenantName, resetTo string) string {
+	return fmt.Sprintf(`
+resource "astra_streaming_tenant" "streaming_tenant_1" {
+  deletion_protection = false
+  tenant_name         = "%s"
+  topic               = "terraformtest"
+  cloud_provider      = "gcp"
+  region              = "us-east4"
+  user_email          = "test@datastax.com"
+}
+
+resource "astra_streaming_topic" "topic_1" {
+  deletion_protection = false
+  cluster             = astra_streaming_tenant.streaming_tenant_1.cluster_name
+  tenant              = astra_streaming_tenant.streaming_tenant_1.tenant_name
+  namespace           = "default"
+  topic               = "terraform-subscription-test"
+}
+
+resource "astra_streaming_subscription" "subscription_1" {
+  deletion_protection = false
+  cluster             = astra_streaming_tenant.streaming_tenant_1.cluster_name
+  topic_fqn           = astra_streaming_topic.topic_1.topic_fqn
+  subscription_name   = "terraform-test"
+  initial_position    = "earliest"
+  %s
+}
+`, tenantName, resetTo)
+}
+
+func TestStreamingSubscriptionID(t *testing.T) {
+	model := &StreamingSubscriptionResourceModel{
+		Cluster:          types.StringValue("pulsar-gcp-useast1"),
+		TopicFQN:         types.StringValue("persistent://my-tenant/default/my-topic"),
+		SubscriptionName: types.StringValue("my-subscription"),
+	}
+	tenant, namespace, topic := model.topicPath()
+	assert.Equal(t, "my-tenant", tenant)
+	assert.Equal(t, "default", namespace)
+	assert.Equal(t, "my-topic", topic)
+	assert.Equal(t, "pulsar-gcp-useast1/my-tenant/default/my-topic/my-subscription", model.generateID())
+	assert.True(t, subscriptionIDRegex.MatchString(model.generateID()))
+
+	model.TopicFQN = types.StringValue("non-persistent://my-tenant/default/my-topic")
+	tenant, _, _ = model.topicPath()
+	assert.Equal(t, "", tenant)
+}
+
+func TestSubscriptionInitialPosition(t *testing.T) {
+	position, err := subscriptionInitialPosition("earliest")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), *position.LedgerId)
+	assert.Equal(t, int64(-1), *position.EntryId)
+
+	position, err = subscriptionInitialPosition("latest")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), *position.LedgerId)
+	assert.Equal(t, int64(math.MaxInt64), *position.EntryId)
+
+	position, err = subscriptionInitialPosition("123:45")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(123), *position.LedgerId)
+	assert.Equal(t, int64(45), *position.EntryId)
+	assert.Nil(t, position.PartitionIndex)
+
+	_, err = subscriptionInitialPosition("first")
+	assert.NotNil(t, err)
+	_, err = subscriptionInitialPosition("99999999999999999999:1")
+	assert.NotNil(t, err)
+}
+
+func TestSubscriptionResetToFormat(t *testing.T) {
+	assert.True(t, subscriptionTimestampRegex.MatchString("2024-01-02T15:04:05Z"))
+	assert.True(t, subscriptionTimestampRegex.MatchString("2024-01-02T15:04:05.123+02:00"))
+	assert.False(t, subscriptionTimestampRegex.MatchString("2024-01-02"))
+	assert.True(t, subscriptionMessageIDRegex.MatchString("123:45"))
+	assert.False(t, subscriptionMessageIDRegex.MatchString("123:45:0"))
+}
+
+func TestSubscriptionReplicated(t *testing.T) {
+	assert.False(t, subscriptionReplicated(map[string]bool{}))
+	assert.True(t, subscriptionReplicated(map[string]bool{
+		"persistent://my-tenant/default/my-topic-partition-0": true,
+		"persistent://my-tenant/default/my-topic-partition-1": true,
+	}))
+	assert.False(t, subscriptionReplicated(map[string]bool{
+		"persistent://my-tenant/default/my-topic-partition-0": true,
+		"persistent://my-tenant/default/my-topic-partition-1": false,
+	}))
+}
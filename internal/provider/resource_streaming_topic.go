@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,7 +19,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -29,6 +29,7 @@ import (
 var (
 	_ resource.Resource                = &StreamingTopicResource{}
 	_ resource.ResourceWithConfigure   = &StreamingTopicResource{}
+	_ resource.ResourceWithModifyPlan  = &StreamingTopicResource{}
 	_ resource.ResourceWithImportState = &StreamingTopicResource{}
 )
 
@@ -57,6 +58,8 @@ type StreamingTopicResourceModel struct {
 	NumPartitions      types.Int64           `tfsdk:"num_partitions" json:"num_partitions,omitempty"`
 	DeletionProtection types.Bool            `tfsdk:"deletion_protection" json:"deletion_protection,omitempty"`
 	Schema             *StreamingTopicSchema `tfsdk:"schema" json:"schema,omitempty"`
+	SchemaVersion      types.Int64           `tfsdk:"schema_version" json:"schema_version,omitempty"`
+	Policies           types.Object          `tfsdk:"policies" json:"policies,omitempty"`
 	TopicFQN           types.String          `tfsdk:"topic_fqn" json:"topic_fqn,omitempty"`
 }
 
@@ -173,10 +176,14 @@ func (r *StreamingTopicResource) Schema(_ context.Context, _ resource.SchemaRequ
 				},
 			},
 			"num_partitions": schema.Int64Attribute{
-				Description: "Number of partitions for a partitioned topic.  This field must not be set for a non-partitioned topic.",
-				Optional:    true,
+				Description: "Number of partitions for a partitioned topic.  This field must not be set for a non-partitioned topic. " +
+					"Increasing the number of partitions updates the topic in place, decreasing it replaces the topic.",
+				Optional: true,
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					int64planmodifier.RequiresReplaceIf(requiresReplaceIfPartitionsDecreased,
+						"If the number of partitions decreases, Terraform will destroy and recreate the resource.",
+						"If the number of partitions decreases, Terraform will destroy and recreate the resource.",
+					),
 				},
 			},
 			"deletion_protection": schema.BoolAttribute{
@@ -186,11 +193,9 @@ func (r *StreamingTopicResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Default:     booldefault.StaticBool(true),
 			},
 			"schema": schema.SingleNestedAttribute{
-				Description: "Pulsar topic schema.",
-				Optional:    true,
-				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
-				},
+				Description: "Pulsar topic schema. Changing the schema uploads a new version of the schema, after testing that it is " +
+					"compatible with the existing schema according to the schema compatibility strategy of the namespace.",
+				Optional: true,
 				Attributes: map[string]schema.Attribute{
 					"type": schema.StringAttribute{
 						Description: "Type of the schema, e.g. JSON",
@@ -207,6 +212,14 @@ func (r *StreamingTopicResource) Schema(_ context.Context, _ resource.SchemaRequ
 					},
 				},
 			},
+			"schema_version": schema.Int64Attribute{
+				Description: "Version of the topic schema.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"policies": pulsarTopicPoliciesSchema,
 			"topic_fqn": schema.StringAttribute{
 				Description: "Fully qualified name of the topic, for example 'persistent://mytenant/namespace1/mytopic'",
 				Computed:    true,
@@ -224,6 +237,111 @@ func (r *StreamingTopicResource) Configure(_ context.Context, req resource.Confi
 	r.clients = req.ProviderData.(*astraClients2)
 }
 
+// ModifyPlan checks that policies are only set on persistent topics, that schema changes are compatible with the
+// existing schema, and warns that increasing the number of partitions of a topic rebalances its consumers.
+func (r *StreamingTopicResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// resource is being destroyed
+		return
+	}
+
+	var persistent types.Bool
+	var policies types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("persistent"), &persistent)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("policies"), &policies)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !policies.IsNull() && !persistent.IsUnknown() && !persistent.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policies"),
+			"topic policies are not supported",
+			"Topic policies can only be set on persistent topics.")
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		// resource is being created
+		return
+	}
+
+	var planPartitions, statePartitions types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("num_partitions"), &planPartitions)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("num_partitions"), &statePartitions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if partitionsIncreased(statePartitions, planPartitions) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("num_partitions"),
+			"topic partitions will be increased",
+			fmt.Sprintf("The number of partitions will be increased from %d to %d. The subscriptions of the topic will be rebalanced across the new partitions, "+
+				"which may temporarily disconnect consumers and break the ordering of messages with the same key.", statePartitions.ValueInt64(), planPartitions.ValueInt64()))
+	}
+
+	resp.Diagnostics.Append(r.modifySchemaPlan(ctx, req, resp)...)
+}
+
+// modifySchemaPlan marks the schema version unknown when the schema changes, and fails the plan if the new schema
+// is not compatible with the existing schema of the topic.
+func (r *StreamingTopicResource) modifySchemaPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var plan, state StreamingTopicResourceModel
+	diags := req.State.Get(ctx, &state)
+	var planSchema types.Object
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("schema"), &planSchema)...)
+	if diags.HasError() {
+		return diags
+	}
+	planSchemaValue, err := planSchema.ToTerraformValue(ctx)
+	if err != nil {
+		diags.AddError("failed to read the planned topic schema", err.Error())
+		return diags
+	}
+	if !planSchemaValue.IsFullyKnown() {
+		// the schema can't be tested until it is known
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("schema_version"), types.Int64Unknown())...)
+		return diags
+	}
+	diags.Append(req.Plan.Get(ctx, &plan)...)
+	if diags.HasError() || plan.Schema.equal(state.Schema) {
+		return diags
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("schema_version"), types.Int64Unknown())...)
+	if plan.Schema == nil || r.clients == nil {
+		return diags
+	}
+	if len(resp.RequiresReplace) > 0 || plan.generateStreamingTopicID() != state.generateStreamingTopicID() {
+		// a new topic is created with the planned schema, which doesn't need to be compatible with the existing one
+		return diags
+	}
+
+	tenant := state.Tenant.ValueString()
+	if tenant == "" {
+		tenant = state.TenantName.ValueString()
+	}
+	pulsarRequestEditor := setPulsarClusterHeaders(state.Cluster.ValueString())
+	diags.Append(testTopicSchemaCompatibility(ctx, r.clients.pulsarAdminClient, tenant, state.Namespace.ValueString(), state.Topic.ValueString(),
+		plan.Schema, pulsarRequestEditor)...)
+	return diags
+}
+
+// requiresReplaceIfPartitionsDecreased requires the replacement of the topic if the number of partitions decreased, or
+// was added or removed. Pulsar only supports increasing the partitions of a topic.
+func requiresReplaceIfPartitionsDecreased(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsUnknown() {
+		return
+	}
+	resp.RequiresReplace = !partitionsIncreased(req.StateValue, req.PlanValue)
+}
+
+// partitionsIncreased returns true if both numbers of partitions are known and the planned one is greater.
+func partitionsIncreased(state, plan types.Int64) bool {
+	if state.IsNull() || state.IsUnknown() || plan.IsNull() || plan.IsUnknown() {
+		return false
+	}
+	return plan.ValueInt64() > state.ValueInt64()
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *StreamingTopicResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan StreamingTopicResourceModel
@@ -306,6 +424,20 @@ func (r *StreamingTopicResource) Create(ctx context.Context, req resource.Create
 		respHTTP, err := pulsarClient.SchemasResourcePostSchema(ctx, tenant, namespace, topic, &params, schemaBody, streamingRequestHeaders)
 		resp.Diagnostics.Append(HTTPResponseDiagWarn(respHTTP, err, "Failed to update topic schema")...)
 	}
+	schemaVersion, diags := getTopicSchemaVersion(ctx, pulsarClient, tenant, namespace, topic, streamingRequestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SchemaVersion = schemaVersion
+
+	if !plan.Policies.IsNull() {
+		plan.Policies, diags = applyTopicPolicies(ctx, pulsarClient, tenant, namespace, topic, plan.Policies, streamingRequestHeaders)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	plan.TopicFQN = types.StringValue(plan.getTopicFQN())
 	plan.ID = types.StringValue(plan.generateStreamingTopicID())
@@ -384,7 +516,10 @@ func (r *StreamingTopicResource) Read(ctx context.Context, req resource.ReadRequ
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get topic schema", err.Error())
 		return
-	} else if schemaResp.StatusCode() > 299 && schemaResp.StatusCode() != 404 {
+	} else if schemaResp.StatusCode() == 404 {
+		state.Schema = nil
+		state.SchemaVersion = types.Int64Null()
+	} else if schemaResp.StatusCode() > 299 {
 		resp.Diagnostics.Append(HTTPResponseDiagWarnWithBody(schemaResp.StatusCode(), schemaResp.Body, err, "failed to get topic schema")...)
 	} else if schemaResp.JSON200 != nil {
 		state.Schema = &StreamingTopicSchema{
@@ -392,6 +527,17 @@ func (r *StreamingTopicResource) Read(ctx context.Context, req resource.ReadRequ
 			Schema:     schemaResp.JSON200.Data,
 			Properties: schemaResp.JSON200.Properties,
 		}
+		state.SchemaVersion = types.Int64PointerValue(schemaResp.JSON200.Version)
+	}
+
+	// Topic policies are only read when they are managed by Terraform, otherwise they are inherited from the namespace
+	if !state.Policies.IsNull() && state.Persistent.ValueBool() {
+		policies, diags := getPulsarTopicPolicies(ctx, pulsarClient, tenant, namespace, topic, streamingRequestHeaders)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Policies = policies
 	}
 
 	state.TopicFQN = types.StringValue(state.getTopicFQN())
@@ -435,9 +581,79 @@ func parseNonPersistentPartitionedTopicResponse(state *StreamingTopicResourceMod
 	return diags
 }
 
+// equal returns true if both schemas have the same type, definition and properties.
+func (s *StreamingTopicSchema) equal(other *StreamingTopicSchema) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	return reflect.DeepEqual(s, other)
+}
+
+// testTopicSchemaCompatibility tests that a schema is compatible with the existing schema of a topic, according to
+// the schema compatibility strategy of its namespace.
+func testTopicSchemaCompatibility(ctx context.Context, client *pulsaradmin.ClientWithResponses, tenant, namespace, topic string,
+	topicSchema *StreamingTopicSchema, requestEditors ...pulsaradmin.RequestEditorFn) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	params := pulsaradmin.SchemasResourceTestCompatibilityParams{}
+	body := pulsaradmin.SchemasResourceTestCompatibilityJSONRequestBody{
+		Type:       topicSchema.Type,
+		Schema:     topicSchema.Schema,
+		Properties: topicSchema.Properties,
+	}
+	compatResp, err := client.SchemasResourceTestCompatibilityWithResponse(ctx, tenant, namespace, topic, &params, body, requestEditors...)
+	if err != nil {
+		diags.AddAttributeError(path.Root("schema"), "failed to test topic schema compatibility", err.Error())
+		return diags
+	}
+	if compatResp.StatusCode() == 409 {
+		// incompatible schemas are rejected with the details of the incompatibility
+		diags.AddAttributeError(path.Root("schema"), "topic schema is not compatible with the existing schema", string(compatResp.Body))
+		return diags
+	}
+	if compatResp.StatusCode() > 299 {
+		diags.AddAttributeError(path.Root("schema"), "failed to test topic schema compatibility",
+			fmt.Sprintf("Received status code: '%v', with message: %s", compatResp.StatusCode(), string(compatResp.Body)))
+		return diags
+	}
+	if compatResp.JSON200 != nil && compatResp.JSON200.Compatibility != nil && !*compatResp.JSON200.Compatibility {
+		strategy := "unknown"
+		if compatResp.JSON200.SchemaCompatibilityStrategy != nil {
+			strategy = *compatResp.JSON200.SchemaCompatibilityStrategy
+		}
+		diags.AddAttributeError(path.Root("schema"), "topic schema is not compatible with the existing schema",
+			fmt.Sprintf("The schema of topic '%s/%s/%s' is not compatible with the existing schema according to the schema compatibility strategy '%s' of the namespace. "+
+				"Change the schema or the `schema_compatibility_strategy` policy of the namespace.", tenant, namespace, topic, strategy))
+	}
+	return diags
+}
+
+// getTopicSchemaVersion returns the version of the latest schema of a topic, or null if the topic has no schema.
+func getTopicSchemaVersion(ctx context.Context, client *pulsaradmin.ClientWithResponses, tenant, namespace, topic string, requestEditors ...pulsaradmin.RequestEditorFn) (types.Int64, diag.Diagnostics) {
+	params := pulsaradmin.SchemasResourceGetSchemaParams{}
+	schemaResp, err := client.SchemasResourceGetSchemaWithResponse(ctx, tenant, namespace, topic, &params, requestEditors...)
+	if err != nil {
+		return types.Int64Null(), diag.Diagnostics{diag.NewErrorDiagnostic("failed to get topic schema", err.Error())}
+	}
+	if schemaResp.StatusCode() == 404 {
+		return types.Int64Null(), nil
+	}
+	diags := HTTPResponseDiagErrWithBody(schemaResp.StatusCode(), schemaResp.Body, err, "failed to get topic schema")
+	if diags.HasError() || schemaResp.JSON200 == nil {
+		return types.Int64Null(), diags
+	}
+	return types.Int64PointerValue(schemaResp.JSON200.Version), diags
+}
+
+// Update increases the number of partitions of the topic, uploads a new version of the schema and applies the topic
+// policies, the other attributes are updated in the state only.
 func (r *StreamingTopicResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan StreamingTopicResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var state StreamingTopicResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if plan.Cluster.ValueString() == "" {
 		cluster := getPulsarCluster(plan.Cluster.ValueString(), plan.CloudProvider.ValueString(), plan.Region.ValueString(), r.clients.streamingClusterSuffix)
@@ -447,6 +663,90 @@ func (r *StreamingTopicResource) Update(ctx context.Context, req resource.Update
 		plan.Tenant = plan.TenantName
 	}
 
+	// the replacement of the topic is only planned when the number of partitions is known during the plan
+	if !plan.NumPartitions.Equal(state.NumPartitions) && !partitionsIncreased(state.NumPartitions, plan.NumPartitions) {
+		resp.Diagnostics.AddAttributeError(path.Root("num_partitions"), "topic partitions cannot be decreased",
+			fmt.Sprintf("The number of partitions of the topic can only be increased, but it would change from %s to %s. "+
+				"Replace the topic to decrease its number of partitions.", state.NumPartitions, plan.NumPartitions))
+		return
+	}
+
+	if plan.Partitioned.ValueBool() && partitionsIncreased(state.NumPartitions, plan.NumPartitions) {
+		pulsarClient := r.clients.pulsarAdminClient
+		pulsarRequestEditor := setPulsarClusterHeaders(plan.Cluster.ValueString())
+		tenant := plan.Tenant.ValueString()
+		namespace := plan.Namespace.ValueString()
+		topic := plan.Topic.ValueString()
+		topicRequestBody := strings.NewReader(strconv.FormatInt(plan.NumPartitions.ValueInt64(), 10))
+		if plan.Persistent.ValueBool() {
+			topicParams := pulsaradmin.PersistentTopicsUpdatePartitionedTopicParams{}
+			respHTTP, err := pulsarClient.PersistentTopicsUpdatePartitionedTopicWithBody(ctx, tenant, namespace, topic, &topicParams, "application/json", topicRequestBody, pulsarRequestEditor)
+			resp.Diagnostics.Append(HTTPResponseDiagErr(respHTTP, err, "failed to update topic partitions")...)
+		} else {
+			topicParams := pulsaradmin.NonPersistentTopicsUpdatePartitionedTopicParams{}
+			respHTTP, err := pulsarClient.NonPersistentTopicsUpdatePartitionedTopicWithBody(ctx, tenant, namespace, topic, &topicParams, "application/json", topicRequestBody, pulsarRequestEditor)
+			resp.Diagnostics.Append(HTTPResponseDiagErr(respHTTP, err, "failed to update topic partitions")...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !plan.Schema.equal(state.Schema) {
+		pulsarClient := r.clients.pulsarAdminClient
+		pulsarRequestEditor := setPulsarClusterHeaders(plan.Cluster.ValueString())
+		tenant := plan.Tenant.ValueString()
+		namespace := plan.Namespace.ValueString()
+		topic := plan.Topic.ValueString()
+		if plan.Schema == nil {
+			params := pulsaradmin.SchemasResourceDeleteSchemaParams{}
+			respHTTP, err := pulsarClient.SchemasResourceDeleteSchema(ctx, tenant, namespace, topic, &params, pulsarRequestEditor)
+			resp.Diagnostics.Append(HTTPResponseDiagErr(respHTTP, err, "failed to delete topic schema")...)
+		} else {
+			// the compatibility was tested during the plan, but the schema may have changed since then
+			resp.Diagnostics.Append(testTopicSchemaCompatibility(ctx, pulsarClient, tenant, namespace, topic, plan.Schema, pulsarRequestEditor)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			params := pulsaradmin.SchemasResourcePostSchemaParams{}
+			schemaBody := pulsaradmin.SchemasResourcePostSchemaJSONRequestBody{
+				Type:       plan.Schema.Type,
+				Schema:     plan.Schema.Schema,
+				Properties: plan.Schema.Properties,
+			}
+			respHTTP, err := pulsarClient.SchemasResourcePostSchema(ctx, tenant, namespace, topic, &params, schemaBody, pulsarRequestEditor)
+			resp.Diagnostics.Append(HTTPResponseDiagErr(respHTTP, err, "failed to update topic schema")...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		schemaVersion, diags := getTopicSchemaVersion(ctx, pulsarClient, tenant, namespace, topic, pulsarRequestEditor)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.SchemaVersion = schemaVersion
+	}
+
+	if !plan.Policies.IsNull() || !state.Policies.IsNull() {
+		pulsarClient := r.clients.pulsarAdminClient
+		pulsarRequestEditor := setPulsarClusterHeaders(plan.Cluster.ValueString())
+		tenant := plan.Tenant.ValueString()
+		namespace := plan.Namespace.ValueString()
+		topic := plan.Topic.ValueString()
+		if plan.Policies.IsNull() {
+			// the policies are no longer managed by Terraform, so the topic falls back to the namespace policies
+			resp.Diagnostics.Append(removeTopicPolicies(ctx, pulsarClient, tenant, namespace, topic, pulsarRequestEditor)...)
+		} else {
+			var diags diag.Diagnostics
+			plan.Policies, diags = applyTopicPolicies(ctx, pulsarClient, tenant, namespace, topic, plan.Policies, pulsarRequestEditor)
+			resp.Diagnostics.Append(diags...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	plan.TopicFQN = types.StringValue(plan.getTopicFQN())
 	plan.ID = types.StringValue(plan.generateStreamingTopicID())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -524,6 +824,7 @@ func (r *StreamingTopicResource) ImportState(ctx context.Context, req resource.I
 	topic.CloudProvider = types.StringValue(provider)
 	topic.Region = types.StringValue(region)
 	topic.DeletionProtection = types.BoolValue(true)
+	topic.Policies = types.ObjectNull(pulsarTopicPoliciesAttributeTypes)
 	// var diags diag.Diagnostics
 	// topic.Schema, diags = types.ObjectValue(StreamingTopicSchemaAttributeTypes, nil)
 	// resp.Diagnostics.Append(diags...)
@@ -1,9 +1,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/datastax/pulsar-admin-client-go/src/pulsaradmin"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/stretchr/testify/assert"
 )
@@ -110,3 +120,173 @@ func TestTopicNameValidation(t *testing.T) {
 		assert.NotNil(t, err)
 	}
 }
+
+func TestPartitionsIncreased(t *testing.T) {
+	assert.True(t, partitionsIncreased(types.Int64Value(4), types.Int64Value(8)))
+	assert.False(t, partitionsIncreased(types.Int64Value(8), types.Int64Value(4)))
+	assert.False(t, partitionsIncreased(types.Int64Value(4), types.Int64Value(4)))
+	// switching between partitioned and non-partitioned topics is not an increase
+	assert.False(t, partitionsIncreased(types.Int64Null(), types.Int64Value(4)))
+	assert.False(t, partitionsIncreased(types.Int64Value(4), types.Int64Null()))
+	assert.False(t, partitionsIncreased(types.Int64Value(4), types.Int64Unknown()))
+}
+
+func TestUpdateTopicPartitionsDecreased(t *testing.T) {
+	r := &StreamingTopicResource{clients: &astraClients2{}}
+	ctx := context.Background()
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	newModel := func(numPartitions types.Int64) *StreamingTopicResourceModel {
+		return &StreamingTopicResourceModel{
+			ID:                 types.StringValue("pulsar-gcp-useast1:persistent://my-tenant/default/my-topic"),
+			Cluster:            types.StringValue("pulsar-gcp-useast1"),
+			CloudProvider:      types.StringNull(),
+			Region:             types.StringNull(),
+			Tenant:             types.StringValue("my-tenant"),
+			TenantName:         types.StringNull(),
+			Namespace:          types.StringValue("default"),
+			Topic:              types.StringValue("my-topic"),
+			Persistent:         types.BoolValue(true),
+			Partitioned:        types.BoolValue(true),
+			NumPartitions:      numPartitions,
+			DeletionProtection: types.BoolValue(false),
+			SchemaVersion:      types.Int64Null(),
+			Policies:           types.ObjectNull(pulsarTopicPoliciesAttributeTypes),
+			TopicFQN:           types.StringValue("persistent://my-tenant/default/my-topic"),
+		}
+	}
+
+	// the number of partitions was unknown during the plan, so the replacement of the topic was not planned
+	req := fwresource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	assert.False(t, req.State.Set(ctx, newModel(types.Int64Value(8))).HasError())
+	assert.False(t, req.Plan.Set(ctx, newModel(types.Int64Value(4))).HasError())
+	resp := &fwresource.UpdateResponse{State: req.State}
+	r.Update(ctx, req, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "topic partitions cannot be decreased", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestStreamingTopicSchemaEqual(t *testing.T) {
+	schemaType := "JSON"
+	definition := `{"type": "record", "name": "User", "fields": [{"name": "name", "type": "string"}]}`
+	otherDefinition := `{"type": "record", "name": "User", "fields": [{"name": "email", "type": ["null", "string"], "default": null}]}`
+
+	var topicSchema *StreamingTopicSchema
+	assert.True(t, topicSchema.equal(nil))
+	assert.False(t, topicSchema.equal(&StreamingTopicSchema{Type: &schemaType, Schema: &definition}))
+	topicSchema = &StreamingTopicSchema{Type: &schemaType, Schema: &definition}
+	assert.False(t, topicSchema.equal(nil))
+	assert.True(t, topicSchema.equal(&StreamingTopicSchema{Type: &schemaType, Schema: &definition}))
+	assert.False(t, topicSchema.equal(&StreamingTopicSchema{Type: &schemaType, Schema: &otherDefinition}))
+	assert.False(t, topicSchema.equal(&StreamingTopicSchema{Type: &schemaType, Schema: &definition, Properties: &map[string]string{"a": "b"}}))
+}
+
+func TestTestTopicSchemaCompatibility(t *testing.T) {
+	statusCode := http.StatusOK
+	responseBody := `{"compatibility": true, "schemaCompatibilityStrategy": "FULL"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/admin/v2/schemas/my-tenant/default/my-topic/compatibility", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"type": "JSON", "schema": "{}"}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(responseBody))
+	}))
+	defer server.Close()
+
+	client, err := pulsaradmin.NewClientWithResponses(server.URL + "/admin/v2")
+	assert.Nil(t, err)
+
+	schemaType := "JSON"
+	definition := "{}"
+	topicSchema := &StreamingTopicSchema{Type: &schemaType, Schema: &definition}
+	diags := testTopicSchemaCompatibility(context.Background(), client, "my-tenant", "default", "my-topic", topicSchema)
+	assert.False(t, diags.HasError())
+
+	responseBody = `{"compatibility": false, "schemaCompatibilityStrategy": "FULL"}`
+	diags = testTopicSchemaCompatibility(context.Background(), client, "my-tenant", "default", "my-topic", topicSchema)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), "'FULL'")
+
+	statusCode = http.StatusConflict
+	responseBody = `{"reason": "Incompatible schema: field email has no default value"}`
+	diags = testTopicSchemaCompatibility(context.Background(), client, "my-tenant", "default", "my-topic", topicSchema)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), "field email has no default value")
+}
+
+func TestModifySchemaPlan(t *testing.T) {
+	compatibilityRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/admin/v2/schemas/my-tenant/default/my-topic/compatibility", r.URL.Path)
+		compatibilityRequests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"compatibility": false, "schemaCompatibilityStrategy": "FULL"}`))
+	}))
+	defer server.Close()
+
+	client, err := pulsaradmin.NewClientWithResponses(server.URL + "/admin/v2")
+	assert.Nil(t, err)
+	r := &StreamingTopicResource{clients: &astraClients2{pulsarAdminClient: client}}
+
+	ctx := context.Background()
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	schemaType := "JSON"
+	stateDefinition, planDefinition := `{"type": "record"}`, `{"type": "record", "fields": []}`
+	newModel := func(namespace, definition string) *StreamingTopicResourceModel {
+		return &StreamingTopicResourceModel{
+			ID:                 types.StringValue("pulsar-gcp-useast1:persistent://my-tenant/" + namespace + "/my-topic"),
+			Cluster:            types.StringValue("pulsar-gcp-useast1"),
+			CloudProvider:      types.StringNull(),
+			Region:             types.StringNull(),
+			Tenant:             types.StringValue("my-tenant"),
+			TenantName:         types.StringNull(),
+			Namespace:          types.StringValue(namespace),
+			Topic:              types.StringValue("my-topic"),
+			Persistent:         types.BoolValue(true),
+			Partitioned:        types.BoolValue(false),
+			NumPartitions:      types.Int64Null(),
+			DeletionProtection: types.BoolValue(false),
+			Schema:             &StreamingTopicSchema{Type: &schemaType, Schema: &definition},
+			SchemaVersion:      types.Int64Value(0),
+			Policies:           types.ObjectNull(pulsarTopicPoliciesAttributeTypes),
+			TopicFQN:           types.StringValue("persistent://my-tenant/" + namespace + "/my-topic"),
+		}
+	}
+	modifySchemaPlan := func(plan *StreamingTopicResourceModel, requiresReplace bool) *fwresource.ModifyPlanResponse {
+		req := fwresource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}
+		assert.False(t, req.State.Set(ctx, newModel("default", stateDefinition)).HasError())
+		assert.False(t, req.Plan.Set(ctx, plan).HasError())
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		if requiresReplace {
+			resp.RequiresReplace = path.Paths{path.Root("namespace")}
+		}
+		resp.Diagnostics.Append(r.modifySchemaPlan(ctx, req, resp)...)
+		return resp
+	}
+
+	// the schema of the existing topic is evolved, it must be compatible
+	resp := modifySchemaPlan(newModel("default", planDefinition), false)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, 1, compatibilityRequests)
+
+	// a replaced topic is created with the new schema
+	resp = modifySchemaPlan(newModel("other", planDefinition), true)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, 1, compatibilityRequests)
+	var schemaVersion types.Int64
+	resp.Plan.GetAttribute(ctx, path.Root("schema_version"), &schemaVersion)
+	assert.True(t, schemaVersion.IsUnknown())
+
+	resp = modifySchemaPlan(newModel("other", planDefinition), false)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, 1, compatibilityRequests)
+}
@@ -2,120 +2,231 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
 
-	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+var (
+	_ resource.Resource                 = &tableResource{}
+	_ resource.ResourceWithConfigure    = &tableResource{}
+	_ resource.ResourceWithImportState  = &tableResource{}
+	_ resource.ResourceWithIdentity     = &tableResource{}
+	_ resource.ResourceWithUpgradeState = &tableResource{}
 )
 
-func resourceTable() *schema.Resource {
-	return &schema.Resource{
-		Description:   "`astra_table` provides a table resource which represents a table in cassandra.",
-		CreateContext: resourceTableCreate,
-		ReadContext:   resourceTableRead,
-		DeleteContext: resourceTableDelete,
+func NewTableResource() resource.Resource {
+	return &tableResource{}
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+type tableResource struct {
+	clients *astraClients2
+}
+
+type tableResourceModel struct {
+	ID                 types.String        `tfsdk:"id"`
+	Keyspace           types.String        `tfsdk:"keyspace"`
+	Table              types.String        `tfsdk:"table"`
+	DatabaseID         types.String        `tfsdk:"database_id"`
+	Region             types.String        `tfsdk:"region"`
+	ClusteringColumns  types.String        `tfsdk:"clustering_columns"`
+	PartitionKeys      types.String        `tfsdk:"partition_keys"`
+	ColumnDefinitions  []map[string]string `tfsdk:"column_definitions"`
+	DeletionProtection types.Bool          `tfsdk:"deletion_protection"`
+	FailIfNotEmpty     types.Bool          `tfsdk:"fail_if_not_empty"`
+	Timeouts           timeouts.Value      `tfsdk:"timeouts"`
+}
+
+type tableResourceIdentityModel struct {
+	DatabaseID types.String `tfsdk:"database_id"`
+	Keyspace   types.String `tfsdk:"keyspace"`
+	Table      types.String `tfsdk:"table"`
+}
+
+func (r *tableResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table"
+}
 
-		Schema: map[string]*schema.Schema{
-			// Required
-			"keyspace": {
-				Description:      "Keyspace name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character.",
-				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validateKeyspace,
+func (r *tableResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`astra_table` provides a table resource which represents a table in cassandra.\n\n" +
+			"Tables can be imported with an ID of the form `database_id/keyspace/table`, or with an `import` block using the `database_id`, `keyspace` and `table` identity attributes (Terraform v1.12.0 and later).",
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the table, in the format `database_id/keyspace/table`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"table": {
-				Description:      "Table name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character.",
-				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validateKeyspace,
+			"keyspace": schema.StringAttribute{
+				Description: "Keyspace name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyspaceNameRegex, "invalid keyspace name"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table": schema.StringAttribute{
+				Description: "Table name can have up to 48 alpha-numeric characters and contain underscores; only letters are supported as the first character.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyspaceNameRegex, "invalid table name"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"database_id": {
-				Description:  "Astra database to create the keyspace.",
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.IsUUID,
+			"database_id": schema.StringAttribute{
+				Description: "Astra database to create the keyspace.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegex, "must be a valid UUID"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"region": {
-				Description: "region.",
-				Type:        schema.TypeString,
+			"region": schema.StringAttribute{
+				Description: "The region of the database used to manage the table. If this region does not have a healthy datacenter, another region of the database is used. Changing the region does not recreate the table.",
 				Required:    true,
-				ForceNew:    true,
 			},
-			"clustering_columns": {
+			"clustering_columns": schema.StringAttribute{
 				Description: "Clustering column(s), separated by :",
-				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"partition_keys": {
+			"partition_keys": schema.StringAttribute{
 				Description: "Partition key(s), separated by :",
-				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"column_definitions": {
+			"column_definitions": schema.ListAttribute{
 				Description: "A list of table Definitions",
-				Type:        schema.TypeList,
 				Required:    true,
-				ForceNew:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeMap,
-					Elem: &schema.Schema{
-						Type: schema.TypeString,
-					},
+				ElementType: types.MapType{ElemType: types.StringType},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether or not to allow Terraform to destroy the table. Unless this field is set to false in Terraform state, a `terraform destroy` or `terraform apply` command that deletes the table will fail. Defaults to `true`. The state of tables created by provider versions which did not support this attribute is upgraded with `false`, so they stay unprotected until the next `terraform apply`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"fail_if_not_empty": schema.BoolAttribute{
+				Description: "Whether or not to refuse to destroy the table while it still contains rows. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(astraClients).astraClient.(*astra.ClientWithResponses)
-	providerVersion := meta.(astraClients).providerVersion
-	userAgent := meta.(astraClients).userAgent
-	token := meta.(astraClients).token
+func (r *tableResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"database_id": identityschema.StringAttribute{
+				Description:       "The ID of the Astra database.",
+				RequiredForImport: true,
+			},
+			"keyspace": identityschema.StringAttribute{
+				Description:       "The keyspace name.",
+				RequiredForImport: true,
+			},
+			"table": identityschema.StringAttribute{
+				Description:       "The table name.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
 
-	stargateCache := meta.(astraClients).stargateClientCache
+func (r *tableResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.clients = req.ProviderData.(*astraClients2)
+}
 
-	databaseID := d.Get("database_id").(string)
-	keyspaceName := d.Get("keyspace").(string)
-	tableName := d.Get("table").(string)
-	region := d.Get("region").(string)
-	partitionKeys := strings.Split(d.Get("partition_keys").(string), ":")
-	clusteringColumns := strings.Split(d.Get("clustering_columns").(string), ":")
+func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	tableParams := astrarestapi.CreateTableParams{
-		XCassandraToken: token,
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
 	}
 
-	ifnotexists := true
+	client := r.clients.astraClient
+	restClients := r.clients.restClientPool
+	token := r.clients.token
+
+	databaseID := plan.DatabaseID.ValueString()
+	keyspaceName := plan.Keyspace.ValueString()
+	tableName := plan.Table.ValueString()
+	region := plan.Region.ValueString()
 
-	var columnDefinitions, err = makeColumDefinitionsFromResourceData(d)
+	columnDefinitions, err := makeColumnDefinitions(plan.ColumnDefinitions)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddAttributeError(path.Root("column_definitions"), "Invalid column definitions", err.Error())
+		return
 	}
 
 	primaryKey := astrarestapi.PrimaryKey{
-		ClusteringKey: &clusteringColumns,
-		PartitionKey:  partitionKeys,
+		PartitionKey: strings.Split(plan.PartitionKeys.ValueString(), ":"),
+	}
+	if clusteringColumns := plan.ClusteringColumns.ValueString(); clusteringColumns != "" {
+		clusteringKey := strings.Split(clusteringColumns, ":")
+		primaryKey.ClusteringKey = &clusteringKey
+	}
+
+	tableParams := astrarestapi.CreateTableParams{
+		XCassandraToken: token,
 	}
 
+	ifnotexists := true
 	createJSON := astrarestapi.CreateTableJSONRequestBody{
 		ColumnDefinitions: columnDefinitions,
 		IfNotExists:       &ifnotexists,
@@ -124,19 +235,8 @@ func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		TableOptions:      nil,
 	}
 
-	var restClient *astrarestapi.ClientWithResponses
-	if val, ok := stargateCache[databaseID]; ok {
-		restClient = val
-	} else {
-		var err error
-		restClient, err = newRestClient(databaseID, providerVersion, userAgent, region)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
 	//Wait for DB to be in Active status
-	if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+	if err := retry.RetryContext(ctx, createTimeout, func() *retry.RetryError {
 		res, err := client.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
 		// Errors sending request should be retried and are assumed to be transient
 		if err != nil {
@@ -160,162 +260,314 @@ func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta inter
 			// If the database reached a terminal state it will never become active
 			return retry.NonRetryableError(fmt.Errorf("database failed to reach active status: status=%s", db.Status))
 		case astra.ACTIVE:
+			restClient, _, err := restClients.get(ctx, databaseID, region)
+			if err != nil {
+				return retry.NonRetryableError(err)
+			}
 			resp, err := restClient.CreateTableWithResponse(ctx, keyspaceName, &tableParams, createJSON)
 			if err != nil {
-				return retry.NonRetryableError(fmt.Errorf("error adding table (not retrying) err: %s,  body: %s", err, resp.Body))
+				restClients.evict(databaseID)
+				return retry.NonRetryableError(fmt.Errorf("error adding table (not retrying) err: %s", err))
 			} else if resp.StatusCode() == 409 {
 				// DevOps API returns 409 for concurrent modifications, these need to be retried.
 				return retry.RetryableError(fmt.Errorf("error adding table (retrying): %s", resp.Body))
 			} else if resp.StatusCode() >= 400 {
 				return retry.NonRetryableError(fmt.Errorf("error adding table (not retrying): %s", resp.Body))
 			}
-			if err := setTableResourceData(d, databaseID, region, keyspaceName, tableName, primaryKey, columnDefinitions); err != nil {
-				return retry.NonRetryableError(fmt.Errorf("Error setting table data (not retrying) %s", err))
-			}
-
 			return nil
 		default:
 			return retry.RetryableError(fmt.Errorf("expected database to be active but is %s", db.Status))
 		}
 	}); err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error creating table", err.Error())
+		return
 	}
 
-	return nil
+	plan.ID = types.StringValue(tableID(databaseID, keyspaceName, tableName))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 }
 
-func resourceTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	providerVersion := meta.(astraClients).providerVersion
-	userAgent := meta.(astraClients).userAgent
-	token := meta.(astraClients).token
-
-	id := d.Id()
-	databaseID, region, keyspaceName, tableName, err := parseTableID(id)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if region == "" {
-		region = d.Get("region").(string)
-	}
-	if region == "" {
-		return diag.Errorf("missing region for table %s/<region>/%s/%s", databaseID, keyspaceName, tableName)
+func (r *tableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	stargateCache := meta.(astraClients).stargateClientCache
+	restClients := r.clients.restClientPool
+	token := r.clients.token
 
-	var restClient *astrarestapi.ClientWithResponses
-	if val, ok := stargateCache[databaseID]; ok {
-		restClient = val
-	} else {
-		var err error
-		restClient, err = newRestClient(databaseID, providerVersion, userAgent, region)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
+	databaseID := state.DatabaseID.ValueString()
+	keyspaceName := state.Keyspace.ValueString()
+	tableName := state.Table.ValueString()
 
-	fmt.Printf("%v", restClient)
+	// When importing without a region, any healthy region of the database is used
+	restClient, resolvedRegion, err := restClients.get(ctx, databaseID, state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading table", err.Error())
+		return
+	}
+	if state.Region.ValueString() == "" {
+		state.Region = types.StringValue(resolvedRegion)
+	}
 
 	raw := true
 	params := astrarestapi.GetTableParams{
 		Raw:             &raw,
 		XCassandraToken: token,
 	}
-	resp, err := restClient.GetTableWithResponse(ctx, keyspaceName, tableName, &params)
+	tableResp, err := restClient.GetTableWithResponse(ctx, keyspaceName, tableName, &params)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting table (not retrying) err: %w", err))
-	} else if resp.StatusCode() == 409 {
+		restClients.evict(databaseID)
+		resp.Diagnostics.AddError("Error reading table", fmt.Sprintf("error getting table (not retrying) err: %s", err))
+		return
+	} else if tableResp.StatusCode() == 409 {
 		// DevOps API returns 409 for concurrent modifications, these need to be retried.
-		return diag.Errorf("error getting table (retrying): %s", string(resp.Body))
-	} else if resp.StatusCode() >= 400 {
+		resp.Diagnostics.AddError("Error reading table", fmt.Sprintf("error getting table (retrying): %s", string(tableResp.Body)))
+		return
+	} else if tableResp.StatusCode() == 401 || tableResp.StatusCode() >= 500 {
+		restClients.evict(databaseID)
+		resp.Diagnostics.AddError("Error reading table", fmt.Sprintf("error getting table, status code: %d, message: %s", tableResp.StatusCode(), string(tableResp.Body)))
+		return
+	} else if tableResp.StatusCode() >= 400 || tableResp.JSON200 == nil {
 		//table not found
-		d.SetId("")
-		return nil
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	tableData := resp.JSON200
-	if err := setTableResourceData(d, databaseID, region, keyspaceName, tableName, tableData.PrimaryKey, tableData.ColumnDefinitions); err != nil {
-		return diag.FromErr(fmt.Errorf("Error setting table data (not retrying) %s", err))
+	tableData := tableResp.JSON200
+	if len(tableData.PrimaryKey.PartitionKey) == 0 {
+		resp.Diagnostics.AddError("Error reading table", "primary key partition key is missing")
+		return
 	}
+	state.ID = types.StringValue(tableID(databaseID, keyspaceName, tableName))
+	state.PartitionKeys = types.StringValue(strings.Join(tableData.PrimaryKey.PartitionKey, ":"))
+	// only set the clustering columns if they are specified
+	if tableData.PrimaryKey.ClusteringKey != nil && len(*tableData.PrimaryKey.ClusteringKey) > 0 {
+		state.ClusteringColumns = types.StringValue(strings.Join(*tableData.PrimaryKey.ClusteringKey, ":"))
+	}
+	state.ColumnDefinitions = orderColumnDefinitions(state.ColumnDefinitions, tableData.ColumnDefinitions)
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, state.identity())...)
 }
 
-func resourceTableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	providerVersion := meta.(astraClients).providerVersion
-	userAgent := meta.(astraClients).userAgent
-	token := meta.(astraClients).token
+// Update only handles the attributes which are not part of the table definition, all the other attributes force a new table.
+func (r *tableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan tableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	id := d.Id()
-	databaseID, _, keyspaceName, tableName, err := parseTableID(id)
-	if err != nil {
-		return diag.FromErr(err)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
+}
+
+func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	region := d.Get("region").(string)
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Error deleting table", "\"deletion_protection\" must be explicitly set to \"false\" in order to destroy astra_table")
+		return
+	}
 
-	stargateCache := meta.(astraClients).stargateClientCache
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 20*time.Minute)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	var restClient *astrarestapi.ClientWithResponses
-	if val, ok := stargateCache[databaseID]; ok {
-		restClient = val
-	} else {
-		var err error
-		restClient, err = newRestClient(databaseID, providerVersion, userAgent, region)
-		if err != nil {
-			return diag.FromErr(err)
-		}
+	restClients := r.clients.restClientPool
+	token := r.clients.token
+
+	databaseID := state.DatabaseID.ValueString()
+	keyspaceName := state.Keyspace.ValueString()
+	tableName := state.Table.ValueString()
+
+	restClient, _, err := restClients.get(ctx, databaseID, state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting table", err.Error())
+		return
 	}
 
-	fmt.Printf("%v", restClient)
+	if state.FailIfNotEmpty.ValueBool() {
+		if err := checkTableEmpty(ctx, restClient, token, keyspaceName, tableName); err != nil {
+			resp.Diagnostics.AddError("Error deleting table", err.Error())
+			return
+		}
+	}
 
 	params := astrarestapi.DeleteTableParams{
 		XCassandraToken: token,
 	}
-	resp, err := restClient.DeleteTableWithResponse(ctx, keyspaceName, tableName, &params)
+	deleteResp, err := restClient.DeleteTableWithResponse(ctx, keyspaceName, tableName, &params)
 	if err != nil {
-		b := "none"
-		if resp != nil && resp.Body != nil {
-			b = string(resp.Body)
-		}
-		return diag.FromErr(fmt.Errorf("Error getting table (not retrying) err: %s,  body: %s", err, b))
-	} else if resp.StatusCode() == 409 {
+		restClients.evict(databaseID)
+		resp.Diagnostics.AddError("Error deleting table", err.Error())
+		return
+	} else if deleteResp.StatusCode() == 409 {
 		// DevOps API returns 409 for concurrent modifications, these need to be retried.
-		b := "none"
-		if resp.Body != nil {
-			b = string(resp.Body)
+		resp.Diagnostics.AddError("Error deleting table", fmt.Sprintf("error deleting table (retrying): %s", string(deleteResp.Body)))
+		return
+	}
+	// Any other error means the table is already gone
+}
+
+// ImportState accepts either an ID of the form database_id/keyspace/table, or the resource identity.
+func (r *tableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var databaseID, region, keyspaceName, tableName string
+	if req.ID != "" {
+		var err error
+		databaseID, region, keyspaceName, tableName, err = parseTableID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing table", err.Error())
+			return
 		}
-		return diag.FromErr(fmt.Errorf("error getting table (retrying): %s", b))
-	} else if resp.StatusCode() >= 400 {
-		//table not found
-		d.SetId("")
-		return nil
+	} else {
+		var identity tableResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		databaseID = identity.DatabaseID.ValueString()
+		keyspaceName = identity.Keyspace.ValueString()
+		tableName = identity.Table.ValueString()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), tableID(databaseID, keyspaceName, tableName))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_id"), databaseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("keyspace"), keyspaceName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table"), tableName)...)
+	if region != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), region)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fail_if_not_empty"), false)...)
+}
+
+// UpgradeState migrates the state of the SDK implementation of astra_table, which used IDs of the form
+// database_id/keyspace/table or database_id/region/keyspace/table.
+func (r *tableResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                  schema.StringAttribute{Computed: true},
+					"keyspace":            schema.StringAttribute{Required: true},
+					"table":               schema.StringAttribute{Required: true},
+					"database_id":         schema.StringAttribute{Required: true},
+					"region":              schema.StringAttribute{Required: true},
+					"clustering_columns":  schema.StringAttribute{Optional: true},
+					"partition_keys":      schema.StringAttribute{Required: true},
+					"column_definitions":  schema.ListAttribute{Required: true, ElementType: types.MapType{ElemType: types.StringType}},
+					"deletion_protection": schema.BoolAttribute{Optional: true},
+					"fail_if_not_empty":   schema.BoolAttribute{Optional: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					ID                 types.String        `tfsdk:"id"`
+					Keyspace           types.String        `tfsdk:"keyspace"`
+					Table              types.String        `tfsdk:"table"`
+					DatabaseID         types.String        `tfsdk:"database_id"`
+					Region             types.String        `tfsdk:"region"`
+					ClusteringColumns  types.String        `tfsdk:"clustering_columns"`
+					PartitionKeys      types.String        `tfsdk:"partition_keys"`
+					ColumnDefinitions  []map[string]string `tfsdk:"column_definitions"`
+					DeletionProtection types.Bool          `tfsdk:"deletion_protection"`
+					FailIfNotEmpty     types.Bool          `tfsdk:"fail_if_not_empty"`
+				}
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// deletion_protection did not exist in older versions, do not enable it on existing resources
+				upgraded := tableResourceModel{
+					ID:                 types.StringValue(tableID(prior.DatabaseID.ValueString(), prior.Keyspace.ValueString(), prior.Table.ValueString())),
+					Keyspace:           prior.Keyspace,
+					Table:              prior.Table,
+					DatabaseID:         prior.DatabaseID,
+					Region:             prior.Region,
+					ClusteringColumns:  prior.ClusteringColumns,
+					PartitionKeys:      prior.PartitionKeys,
+					ColumnDefinitions:  prior.ColumnDefinitions,
+					DeletionProtection: ElvisTF(&prior.DeletionProtection, types.BoolValue(false)),
+					FailIfNotEmpty:     ElvisTF(&prior.FailIfNotEmpty, types.BoolValue(false)),
+					Timeouts:           nullTimeouts(map[string]attr.Type{"create": types.StringType, "delete": types.StringType}),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
 	}
-	d.SetId("")
-	return nil
 }
 
-func makeColumDefinitionsFromResourceData(d *schema.ResourceData) ([]astrarestapi.ColumnDefinition, error) {
-	columnDefsRaw := d.Get("column_definitions").([]interface{})
+func (m tableResourceModel) identity() tableResourceIdentityModel {
+	return tableResourceIdentityModel{
+		DatabaseID: m.DatabaseID,
+		Keyspace:   m.Keyspace,
+		Table:      m.Table,
+	}
+}
+
+// checkTableEmpty returns an error if the table contains any rows.
+func checkTableEmpty(ctx context.Context, restClient *astrarestapi.ClientWithResponses, token, keyspaceName, tableName string) error {
+	query := url.Values{
+		"page-size": {"1"},
+	}
+	body, statusCode, err := restGet(ctx, restClient, token, fmt.Sprintf("v2/keyspaces/%s/%s/rows", url.PathEscape(keyspaceName), url.PathEscape(tableName)), query)
+	if err != nil {
+		return fmt.Errorf("error checking if table %s.%s is empty: %w", keyspaceName, tableName, err)
+	} else if statusCode == http.StatusNotFound {
+		return nil
+	} else if statusCode >= 400 {
+		return fmt.Errorf("error checking if table %s.%s is empty, status code: %d, message: %s", keyspaceName, tableName, statusCode, string(body))
+	}
+
+	var rows struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return fmt.Errorf("failed to unmarshal rows of table %s.%s: %w", keyspaceName, tableName, err)
+	}
+	if rows.Count == 0 {
+		return nil
+	}
+
+	description := "size unknown"
+	if estimates, err := estimateTablePartitions(ctx, restClient, token, keyspaceName); err == nil {
+		if estimate, ok := estimates[tableName]; ok {
+			description = fmt.Sprintf("~%d partitions", estimate)
+		}
+	}
+	return fmt.Errorf("table %s.%s is not empty (%s) and \"fail_if_not_empty\" is set", keyspaceName, tableName, description)
+}
 
-	var columnDefinitions = make([]astrarestapi.ColumnDefinition, len(columnDefsRaw))
-	for i := 0; i < len(columnDefsRaw); i++ {
-		defMap := columnDefsRaw[i].(map[string]interface{})
+func makeColumnDefinitions(columnDefs []map[string]string) ([]astrarestapi.ColumnDefinition, error) {
+	var columnDefinitions = make([]astrarestapi.ColumnDefinition, len(columnDefs))
+	for i, defMap := range columnDefs {
 		var name string
 		var static bool
 		var typeDef astrarestapi.ColumnDefinitionTypeDefinition
 		for key, value := range defMap {
 			switch key {
 			case "Name":
-				name = value.(string)
+				name = value
 			case "Static":
-				if possibleStatic, err := strconv.ParseBool(value.(string)); err != nil {
-					return nil, fmt.Errorf("bad column definition. Static value \"%s\" is not a valid boolean", value.(string))
+				if possibleStatic, err := strconv.ParseBool(value); err != nil {
+					return nil, fmt.Errorf("bad column definition. Static value \"%s\" is not a valid boolean", value)
 				} else {
 					static = possibleStatic
 				}
 			case "TypeDefinition":
-				typeDef = astrarestapi.ColumnDefinitionTypeDefinition(value.(string))
+				typeDef = astrarestapi.ColumnDefinitionTypeDefinition(value)
 			default:
 				return nil, fmt.Errorf("bad column definition. Key \"%s\" is not one of [Name, Static, TypeDefinition]", key)
 			}
@@ -327,14 +579,12 @@ func makeColumDefinitionsFromResourceData(d *schema.ResourceData) ([]astrarestap
 	return columnDefinitions, nil
 }
 
-func columnDefinitionsMatch(d *schema.ResourceData, columnDefinitions []astrarestapi.ColumnDefinition) bool {
-	// get any existing definitions
-	existingDefs := d.Get("column_definitions").([]interface{})
+func columnDefinitionsMatch(existingDefs []map[string]string, columnDefinitions []astrarestapi.ColumnDefinition) bool {
 	if len(existingDefs) != len(columnDefinitions) {
 		return false
 	}
 	// map of existing definitions by name
-	var existingColumnDefinitions, err = makeColumDefinitionsFromResourceData(d)
+	var existingColumnDefinitions, err = makeColumnDefinitions(existingDefs)
 	if err != nil {
 		return false
 	}
@@ -351,100 +601,58 @@ func columnDefinitionsMatch(d *schema.ResourceData, columnDefinitions []astrares
 		if existingDef.TypeDefinition != def.TypeDefinition {
 			return false
 		}
-		if *existingDef.Static != *def.Static {
+		if astra.BoolValue(existingDef.Static) != astra.BoolValue(def.Static) {
 			return false
 		}
 	}
 	return true
 }
 
-func setColumnDefinitions(d *schema.ResourceData, columnDefinitions []astrarestapi.ColumnDefinition) error {
-	// column definitions we will eventually store on the Resource
+// orderColumnDefinitions converts the column definitions returned by the API to their Terraform representation.  If they
+// match the existing definitions, the order of the existing definitions is preserved to avoid it being detected as a change,
+// otherwise they are sorted by name so they are easily comparable.
+func orderColumnDefinitions(existingDefs []map[string]string, columnDefinitions []astrarestapi.ColumnDefinition) []map[string]string {
 	cdefs := make([]map[string]string, len(columnDefinitions))
 
-	// get any existing definitions
-	existingDefs := d.Get("column_definitions").([]interface{})
-
-	// if we have existing definitions, and they are the same as the ones we are about to set, we need
-	// to preserve the order of the existing definitions to avoid it being detected as a change.
-	if columnDefinitionsMatch(d, columnDefinitions) {
+	if columnDefinitionsMatch(existingDefs, columnDefinitions) {
 		apiDefsByName := make(map[string]astrarestapi.ColumnDefinition)
 		for _, cdef := range columnDefinitions {
 			apiDefsByName[cdef.Name] = cdef
 		}
 		// Preserve the order from existing state
 		for index, existingDef := range existingDefs {
-			defMap := existingDef.(map[string]interface{})
-			name := defMap["Name"].(string)
-
-			// Get the current data from API for this column
-			if cdef, exists := apiDefsByName[name]; exists {
-				cdefs[index] = map[string]string{
-					"Name":           cdef.Name,
-					"TypeDefinition": string(cdef.TypeDefinition),
-					"Static":         strconv.FormatBool(*cdef.Static),
-				}
-			}
-		}
-	} else {
-		// the column definitions we want do not match exactly the existing ones
-		// sort them so they are easily comparable
-		sortedDefs := make([]astrarestapi.ColumnDefinition, len(columnDefinitions))
-		copy(sortedDefs, columnDefinitions)
-		sort.Slice(sortedDefs, func(i, j int) bool {
-			return sortedDefs[i].Name < sortedDefs[j].Name
-		})
-
-		for index, cdef := range sortedDefs {
-			cdefs[index] = map[string]string{
-				"Name":           cdef.Name,
-				"TypeDefinition": string(cdef.TypeDefinition),
-				"Static":         strconv.FormatBool(*cdef.Static),
+			if cdef, exists := apiDefsByName[existingDef["Name"]]; exists {
+				cdefs[index] = columnDefinitionToMap(cdef)
 			}
 		}
+		return cdefs
 	}
 
-	// set the resulting column definitions on the Resource
-	if err := d.Set("column_definitions", cdefs); err != nil {
-		return err
+	sortedDefs := make([]astrarestapi.ColumnDefinition, len(columnDefinitions))
+	copy(sortedDefs, columnDefinitions)
+	sort.Slice(sortedDefs, func(i, j int) bool {
+		return sortedDefs[i].Name < sortedDefs[j].Name
+	})
+	for index, cdef := range sortedDefs {
+		cdefs[index] = columnDefinitionToMap(cdef)
 	}
-	return nil
+	return cdefs
 }
 
-func setTableResourceData(d *schema.ResourceData, databaseID, region, keyspaceName, table string, primaryKey astrarestapi.PrimaryKey, columnDefinitions []astrarestapi.ColumnDefinition) error {
-	d.SetId(fmt.Sprintf("%s/%s/%s", databaseID, keyspaceName, table))
-	if err := d.Set("table", table); err != nil {
-		return err
-	}
-	if err := d.Set("keyspace", keyspaceName); err != nil {
-		return err
-	}
-	if err := d.Set("database_id", databaseID); err != nil {
-		return err
+func columnDefinitionToMap(cdef astrarestapi.ColumnDefinition) map[string]string {
+	return map[string]string{
+		"Name":           cdef.Name,
+		"TypeDefinition": string(cdef.TypeDefinition),
+		"Static":         strconv.FormatBool(astra.BoolValue(cdef.Static)),
 	}
-	if err := d.Set("region", region); err != nil {
-		return err
-	}
-	if primaryKey.PartitionKey == nil || len(primaryKey.PartitionKey) == 0 {
-		return errors.New("primary key partition key is missing")
-	}
-	if err := d.Set("partition_keys", strings.Join(primaryKey.PartitionKey, ":")); err != nil {
-		return err
-	}
-	// only set the clustering columns if they are specified
-	if primaryKey.ClusteringKey != nil && len(*primaryKey.ClusteringKey) > 0 {
-		if err := d.Set("clustering_columns", strings.Join(*primaryKey.ClusteringKey, ":")); err != nil {
-			return err
-		}
-	}
-	// handle column_definitions
-	if err := setColumnDefinitions(d, columnDefinitions); err != nil {
-		return err
-	}
-	return nil
 }
 
-// parseTableID returns the databaseID, region, keyspace, tablename, error (if the format is invalid).
+func tableID(databaseID, keyspaceName, tableName string) string {
+	return fmt.Sprintf("%s/%s/%s", databaseID, keyspaceName, tableName)
+}
+
+// parseTableID returns the databaseID, region, keyspace, tablename, error (if the format is invalid).  The region is
+// only present in the legacy format database_id/region/keyspace/table used by earlier versions of the provider.
 func parseTableID(id string) (string, string, string, string, error) {
 	idParts := strings.Split(id, "/")
 	if len(idParts) == 3 {
@@ -452,5 +660,5 @@ func parseTableID(id string) (string, string, string, string, error) {
 	} else if len(idParts) == 4 {
 		return idParts[0], idParts[1], idParts[2], idParts[3], nil
 	}
-	return "", "", "", "", errors.New("invalid keyspace id format: expected database_id/keyspace/table")
+	return "", "", "", "", errors.New("invalid table id format: expected database_id/keyspace/table")
 }
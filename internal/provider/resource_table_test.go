@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestTable(t *testing.T) {
@@ -13,12 +19,18 @@ func TestTable(t *testing.T) {
 	databaseID := os.Getenv("ASTRA_TEST_DATABASE_ID")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccTableConfiguration(databaseID),
 			},
+			{
+				ResourceName:            "astra_table.table-1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "timeouts"},
+			},
 		},
 	})
 }
@@ -65,6 +77,94 @@ resource "astra_table" "table-1" {
       TypeDefinition: "text"
     }
   ]
+  deletion_protection = false
 }
 `, databaseID)
 }
+
+func TestParseTableID(t *testing.T) {
+	databaseID, region, keyspace, table, err := parseTableID("48bfc13b-c1a5-48db-b70f-b6ef9709872b/ks1/tbl1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"48bfc13b-c1a5-48db-b70f-b6ef9709872b", "", "ks1", "tbl1"}, []string{databaseID, region, keyspace, table})
+
+	// legacy format including the region
+	databaseID, region, keyspace, table, err = parseTableID("48bfc13b-c1a5-48db-b70f-b6ef9709872b/us-east1/ks1/tbl1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"48bfc13b-c1a5-48db-b70f-b6ef9709872b", "us-east1", "ks1", "tbl1"}, []string{databaseID, region, keyspace, table})
+
+	_, _, _, _, err = parseTableID("48bfc13b-c1a5-48db-b70f-b6ef9709872b/ks1")
+	assert.Error(t, err)
+}
+
+func TestOrderColumnDefinitions(t *testing.T) {
+	static := false
+	apiDefs := []astrarestapi.ColumnDefinition{
+		{Name: "a", TypeDefinition: "text", Static: &static},
+		{Name: "c", TypeDefinition: "int", Static: &static},
+		{Name: "b", TypeDefinition: "text", Static: &static},
+	}
+
+	// matching definitions keep the order of the existing state
+	existing := []map[string]string{
+		{"Name": "c", "TypeDefinition": "int", "Static": "false"},
+		{"Name": "b", "TypeDefinition": "text", "Static": "false"},
+		{"Name": "a", "TypeDefinition": "text", "Static": "false"},
+	}
+	assert.Equal(t, existing, orderColumnDefinitions(existing, apiDefs))
+
+	// otherwise the definitions are sorted by name
+	expected := []map[string]string{
+		{"Name": "a", "TypeDefinition": "text", "Static": "false"},
+		{"Name": "b", "TypeDefinition": "text", "Static": "false"},
+		{"Name": "c", "TypeDefinition": "int", "Static": "false"},
+	}
+	assert.Equal(t, expected, orderColumnDefinitions(nil, apiDefs))
+	assert.Equal(t, expected, orderColumnDefinitions(existing[:2], apiDefs))
+}
+
+func TestTableResourceUpgradeStateFromSDK(t *testing.T) {
+	ctx := context.Background()
+	r := &tableResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	columnType := tftypes.Map{ElementType: tftypes.String}
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	priorState := tftypes.NewValue(priorType, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "48bfc13b-c1a5-48db-b70f-b6ef9709872b/us-east1/ks1/tbl1"),
+		"keyspace":            tftypes.NewValue(tftypes.String, "ks1"),
+		"table":               tftypes.NewValue(tftypes.String, "tbl1"),
+		"database_id":         tftypes.NewValue(tftypes.String, "48bfc13b-c1a5-48db-b70f-b6ef9709872b"),
+		"region":              tftypes.NewValue(tftypes.String, "us-east1"),
+		"clustering_columns":  tftypes.NewValue(tftypes.String, nil),
+		"partition_keys":      tftypes.NewValue(tftypes.String, "a"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, nil),
+		"fail_if_not_empty":   tftypes.NewValue(tftypes.Bool, nil),
+		"column_definitions": tftypes.NewValue(tftypes.List{ElementType: columnType}, []tftypes.Value{
+			tftypes.NewValue(columnType, map[string]tftypes.Value{
+				"Name":           tftypes.NewValue(tftypes.String, "a"),
+				"Static":         tftypes.NewValue(tftypes.String, "false"),
+				"TypeDefinition": tftypes.NewValue(tftypes.String, "text"),
+			}),
+		}),
+	})
+
+	req := fwresource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorState},
+	}
+	resp := fwresource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var upgraded tableResourceModel
+	assert.False(t, resp.State.Get(ctx, &upgraded).HasError())
+	assert.Equal(t, "48bfc13b-c1a5-48db-b70f-b6ef9709872b/ks1/tbl1", upgraded.ID.ValueString())
+	assert.Equal(t, "us-east1", upgraded.Region.ValueString())
+	assert.False(t, upgraded.DeletionProtection.ValueBool())
+	assert.False(t, upgraded.FailIfNotEmpty.ValueBool())
+	assert.Equal(t, []map[string]string{{"Name": "a", "Static": "false", "TypeDefinition": "text"}}, upgraded.ColumnDefinitions)
+}
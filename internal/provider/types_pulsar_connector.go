@@ -0,0 +1,580 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// builtinArchivePrefix is the prefix of the archive of the connectors packaged with Astra Streaming.
+const builtinArchivePrefix = "builtin://"
+
+// defaultConnectorRunningTimeout is the default time to wait for the instances of a sink, source or function to be
+// running after a create or update.
+const defaultConnectorRunningTimeout = 10 * time.Minute
+
+// pulsarConsumerSpec is the consumer configuration of an input topic of a Pulsar sink or function.
+type pulsarConsumerSpec struct {
+	SchemaType         *string           `tfsdk:"schema_type"`
+	SerdeClassName     *string           `tfsdk:"serde_class_name"`
+	RegexPattern       *bool             `tfsdk:"regex_pattern"`
+	ReceiverQueueSize  *int32            `tfsdk:"receiver_queue_size"`
+	SchemaProperties   map[string]string `tfsdk:"schema_properties"`
+	ConsumerProperties map[string]string `tfsdk:"consumer_properties"`
+}
+
+// pulsarConnectorResources are the resources allocated to each instance of a Pulsar sink, source or function.
+type pulsarConnectorResources struct {
+	CPU  *float64 `tfsdk:"cpu"`
+	RAM  *int64   `tfsdk:"ram"`
+	Disk *int64   `tfsdk:"disk"`
+}
+
+// pulsarConnectorSecret is a reference to a secret in the secrets provider of the Pulsar functions worker.
+type pulsarConnectorSecret struct {
+	Path *string `tfsdk:"path"`
+	Key  *string `tfsdk:"key"`
+}
+
+func pulsarConsumerSpecsSchema(planModifiers ...planmodifier.Map) schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Description: "Consumer configuration of the input topics, keyed by topic name or topic pattern. " +
+			"Topics listed here are consumed in addition to the other inputs.",
+		Optional:      true,
+		PlanModifiers: planModifiers,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"schema_type": schema.StringAttribute{
+					Description: "Schema type of the topic, for example \"AVRO\", \"JSON\", \"STRING\" or \"AUTO_CONSUME\".",
+					Optional:    true,
+				},
+				"serde_class_name": schema.StringAttribute{
+					Description: "Class name of the SerDe used to deserialize the messages of the topic.",
+					Optional:    true,
+				},
+				"regex_pattern": schema.BoolAttribute{
+					Description: "Whether the key is a regular expression matching topic names.",
+					Optional:    true,
+				},
+				"receiver_queue_size": schema.Int32Attribute{
+					Description: "Size of the consumer receiver queue.",
+					Optional:    true,
+					Validators: []validator.Int32{
+						int32validator.AtLeast(0),
+					},
+				},
+				"schema_properties": schema.MapAttribute{
+					Description: "Schema properties of the topic.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+				"consumer_properties": schema.MapAttribute{
+					Description: "Additional consumer properties.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+			},
+		},
+	}
+}
+
+func pulsarConnectorResourcesSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Resources allocated to each instance. Changing the resources updates the instances in place.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"cpu": schema.Float64Attribute{
+				Description: "Number of CPU cores.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.01),
+				},
+			},
+			"ram": schema.Int64Attribute{
+				Description: "Amount of memory, in bytes.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"disk": schema.Int64Attribute{
+				Description: "Amount of disk, in bytes.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func pulsarConnectorSecretsSchema() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Description: "Secrets passed to the connector by the secrets provider of the functions worker, keyed by the name " +
+			"the connector uses to look up the secret. Changing the secrets updates the instances in place.",
+		Optional: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"path": schema.StringAttribute{
+					Description: "Path of the secret in the secrets provider.",
+					Required:    true,
+				},
+				"key": schema.StringAttribute{
+					Description: "Key of the value within the secret.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+// pulsarConsumerConfigs converts the input specs of the resource model to the Astra Streaming API format.
+func pulsarConsumerConfigs(specs map[string]pulsarConsumerSpec) *map[string]astrastreaming.ConsumerConfig {
+	if len(specs) == 0 {
+		return nil
+	}
+	configs := make(map[string]astrastreaming.ConsumerConfig, len(specs))
+	for topic, spec := range specs {
+		config := astrastreaming.ConsumerConfig{
+			SchemaType:        spec.SchemaType,
+			SerdeClassName:    spec.SerdeClassName,
+			RegexPattern:      spec.RegexPattern,
+			ReceiverQueueSize: spec.ReceiverQueueSize,
+		}
+		if spec.SchemaProperties != nil {
+			config.SchemaProperties = &spec.SchemaProperties
+		}
+		if spec.ConsumerProperties != nil {
+			config.ConsumerProperties = &spec.ConsumerProperties
+		}
+		configs[topic] = config
+	}
+	return &configs
+}
+
+// pulsarImportedInputs returns the inputs of an imported sink or function, from the inputs returned by the functions
+// worker. Pulsar merges all the inputs in the input specs, so they are returned as a topic pattern if the specs include
+// one, and as a sorted list of topics otherwise.
+func pulsarImportedInputs(inputs []string, topicsPattern *string, specs map[string]astrastreaming.ConsumerConfig) ([]string, *string) {
+	if topicsPattern != nil && *topicsPattern != "" {
+		return nil, topicsPattern
+	}
+	topics := append([]string{}, inputs...)
+	for topic, spec := range specs {
+		if spec.RegexPattern != nil && *spec.RegexPattern {
+			return nil, &topic
+		}
+		if !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+	if len(topics) == 0 {
+		return nil, nil
+	}
+	sort.Strings(topics)
+	return topics, nil
+}
+
+// pulsarImportedInputSpecs returns the input specs of an imported sink or function which have custom consumer settings.
+// Pulsar merges all the inputs in the input specs, so the topics with the default settings are imported as inputs, see
+// pulsarImportedInputs.
+func pulsarImportedInputSpecs(specs map[string]astrastreaming.ConsumerConfig) map[string]pulsarConsumerSpec {
+	importedSpecs := map[string]pulsarConsumerSpec{}
+	for topic, config := range specs {
+		if config.RegexPattern != nil && *config.RegexPattern {
+			// topic patterns are imported as `topics_pattern`
+			continue
+		}
+		spec := pulsarConsumerSpec{
+			ReceiverQueueSize: config.ReceiverQueueSize,
+		}
+		if config.SchemaType != nil && *config.SchemaType != "" {
+			spec.SchemaType = config.SchemaType
+		}
+		if config.SerdeClassName != nil && *config.SerdeClassName != "" {
+			spec.SerdeClassName = config.SerdeClassName
+		}
+		if config.SchemaProperties != nil && len(*config.SchemaProperties) > 0 {
+			spec.SchemaProperties = *config.SchemaProperties
+		}
+		if config.ConsumerProperties != nil && len(*config.ConsumerProperties) > 0 {
+			spec.ConsumerProperties = *config.ConsumerProperties
+		}
+		if spec.SchemaType != nil || spec.SerdeClassName != nil || spec.ReceiverQueueSize != nil ||
+			spec.SchemaProperties != nil || spec.ConsumerProperties != nil {
+			importedSpecs[topic] = spec
+		}
+	}
+	if len(importedSpecs) == 0 {
+		return nil
+	}
+	return importedSpecs
+}
+
+// pulsarImportedSecrets returns the secrets of an imported sink or function. The secrets which are not references to a
+// path in the secrets provider are ignored.
+func pulsarImportedSecrets(secrets interface{}) map[string]pulsarConnectorSecret {
+	secretsMap, _ := secrets.(map[string]interface{})
+	importedSecrets := make(map[string]pulsarConnectorSecret, len(secretsMap))
+	for name, secret := range secretsMap {
+		secretMap, _ := secret.(map[string]interface{})
+		path, ok := secretMap["path"].(string)
+		if !ok {
+			continue
+		}
+		importedSecret := pulsarConnectorSecret{Path: &path}
+		if key, ok := secretMap["key"].(string); ok {
+			importedSecret.Key = &key
+		}
+		importedSecrets[name] = importedSecret
+	}
+	if len(importedSecrets) == 0 {
+		return nil
+	}
+	return importedSecrets
+}
+
+// apiResources converts the resources of the resource model to the Astra Streaming API format.
+func (r *pulsarConnectorResources) apiResources() *astrastreaming.Resources {
+	if r == nil {
+		return nil
+	}
+	return &astrastreaming.Resources{
+		Cpu:  r.CPU,
+		Ram:  r.RAM,
+		Disk: r.Disk,
+	}
+}
+
+// refreshPulsarConnectorResources returns the actual values of the configured resources. Pulsar fills in defaults for the
+// resources which are not configured, those are ignored to avoid perpetual diffs.
+func refreshPulsarConnectorResources(prior *pulsarConnectorResources, actual *astrastreaming.Resources) *pulsarConnectorResources {
+	if prior == nil || actual == nil {
+		return prior
+	}
+	refreshed := *prior
+	if refreshed.CPU != nil && actual.Cpu != nil {
+		refreshed.CPU = actual.Cpu
+	}
+	if refreshed.RAM != nil && actual.Ram != nil {
+		refreshed.RAM = actual.Ram
+	}
+	if refreshed.Disk != nil && actual.Disk != nil {
+		refreshed.Disk = actual.Disk
+	}
+	return &refreshed
+}
+
+// pulsarConnectorSecrets converts the secrets of the resource model to the Astra Streaming API format.
+func pulsarConnectorSecrets(secrets map[string]pulsarConnectorSecret) *map[string]map[string]interface{} {
+	if len(secrets) == 0 {
+		return nil
+	}
+	apiSecrets := make(map[string]map[string]interface{}, len(secrets))
+	for name, secret := range secrets {
+		apiSecret := map[string]interface{}{}
+		if secret.Path != nil {
+			apiSecret["path"] = *secret.Path
+		}
+		if secret.Key != nil {
+			apiSecret["key"] = *secret.Key
+		}
+		apiSecrets[name] = apiSecret
+	}
+	return &apiSecrets
+}
+
+// pulsarConnectorDefinition is a connector packaged with Astra Streaming, as returned by the Pulsar functions worker.
+type pulsarConnectorDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SinkClass   string `json:"sinkClass"`
+	SourceClass string `json:"sourceClass"`
+}
+
+// pulsarConfigFieldDefinition is a configuration field of a built-in connector.
+type pulsarConfigFieldDefinition struct {
+	FieldName  string            `json:"fieldName"`
+	TypeName   string            `json:"typeName"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// required returns true if the field must be configured, that is if it is required and has no default value.
+func (f pulsarConfigFieldDefinition) required() bool {
+	return strings.EqualFold(f.Attributes["required"], "true") && f.Attributes["defaultValue"] == ""
+}
+
+// builtinConnectorName returns the name of the connector of a "builtin://" archive.
+func builtinConnectorName(archive string) (string, bool) {
+	if !strings.HasPrefix(archive, builtinArchivePrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(archive, builtinArchivePrefix)
+	return name, name != ""
+}
+
+// listBuiltinPulsarConnectors returns the connectors packaged with the given cluster. The kind is "sinks" or "sources".
+func listBuiltinPulsarConnectors(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, cluster, kind string) ([]pulsarConnectorDefinition, error) {
+	path := fmt.Sprintf("admin/v3/%s/builtin%s", kind, kind)
+	body, statusCode, err := streamingRequest(ctx, streamingClient, cluster, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	} else if statusCode >= 300 {
+		return nil, fmt.Errorf("failed to list built-in %s, status code: %d, message: %s", kind, statusCode, string(body))
+	}
+	var connectors []pulsarConnectorDefinition
+	if err := json.Unmarshal(body, &connectors); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal built-in %s: %w", kind, err)
+	}
+	return connectors, nil
+}
+
+// getPulsarConnectorConfigDefinition returns the configuration fields of a built-in connector. The kind is "sinks" or "sources".
+func getPulsarConnectorConfigDefinition(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, cluster, kind, name string) ([]pulsarConfigFieldDefinition, error) {
+	path := fmt.Sprintf("admin/v3/%s/builtin%s/%s/configdefinition", kind, kind, name)
+	body, statusCode, err := streamingRequest(ctx, streamingClient, cluster, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	} else if statusCode >= 300 {
+		return nil, fmt.Errorf("failed to get config definition of %s, status code: %d, message: %s", name, statusCode, string(body))
+	}
+	var fields []pulsarConfigFieldDefinition
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config definition of %s: %w", name, err)
+	}
+	return fields, nil
+}
+
+// validateConnectorConfigs returns the required fields missing from the configs, and the configs which are not fields
+// of the connector. Both are sorted.
+func validateConnectorConfigs(fields []pulsarConfigFieldDefinition, configs map[string]interface{}) (missing []string, unknown []string) {
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.FieldName] = true
+		if _, ok := configs[field.FieldName]; !ok && field.required() {
+			missing = append(missing, field.FieldName)
+		}
+	}
+	for key := range configs {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unknown)
+	return missing, unknown
+}
+
+// pulsarInstanceStatusModel is the status of an instance of a Pulsar sink, source or function.
+type pulsarInstanceStatusModel struct {
+	InstanceID  types.Int32  `tfsdk:"instance_id"`
+	Running     types.Bool   `tfsdk:"running"`
+	Error       types.String `tfsdk:"error"`
+	NumRestarts types.Int64  `tfsdk:"num_restarts"`
+	WorkerID    types.String `tfsdk:"worker_id"`
+}
+
+var pulsarInstanceStatusAttrTypes = map[string]attr.Type{
+	"instance_id":  types.Int32Type,
+	"running":      types.BoolType,
+	"error":        types.StringType,
+	"num_restarts": types.Int64Type,
+	"worker_id":    types.StringType,
+}
+
+// pulsarConnectorStatusModel holds the computed status attributes of a Pulsar sink, source or function.
+type pulsarConnectorStatusModel struct {
+	NumInstances types.Int32  `tfsdk:"num_instances"`
+	NumRunning   types.Int32  `tfsdk:"num_running"`
+	NumRestarts  types.Int64  `tfsdk:"num_restarts"`
+	LatestError  types.String `tfsdk:"latest_error"`
+	Instances    types.List   `tfsdk:"instances"`
+}
+
+// setStatus sets the status attributes. A nil status sets them to null.
+func (m *pulsarConnectorStatusModel) setStatus(ctx context.Context, status *pulsarConnectorStatus) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Instances, diags = pulsarInstanceStatuses(ctx, status)
+	if status == nil {
+		m.NumInstances = types.Int32Null()
+		m.NumRunning = types.Int32Null()
+		m.NumRestarts = types.Int64Null()
+		m.LatestError = types.StringNull()
+		return diags
+	}
+	m.NumInstances = types.Int32Value(int32(status.NumInstances))
+	m.NumRunning = types.Int32Value(int32(status.NumRunning))
+	m.NumRestarts = types.Int64Value(status.numRestarts())
+	m.LatestError = types.StringValue(status.latestError())
+	return diags
+}
+
+// pulsarConnectorStatusAttributes returns the computed status attributes of a resource, the noun is "sink", "source"
+// or "function".
+func pulsarConnectorStatusAttributes(noun string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"num_instances": schema.Int32Attribute{
+			Description: fmt.Sprintf("Number of instances of the %s.", noun),
+			Computed:    true,
+		},
+		"num_running": schema.Int32Attribute{
+			Description: fmt.Sprintf("Number of running instances of the %s.", noun),
+			Computed:    true,
+		},
+		"num_restarts": schema.Int64Attribute{
+			Description: fmt.Sprintf("Total number of restarts of the %s instances.", noun),
+			Computed:    true,
+		},
+		"latest_error": schema.StringAttribute{
+			Description: "Error of the first failed instance or, if no instance failed, the most recent exception reported by an instance.",
+			Computed:    true,
+		},
+		"instances": pulsarInstanceStatusesSchema(),
+	}
+}
+
+// refreshPulsarConnectorStatus returns the current status of a Pulsar sink, source or function. If wait is true, it
+// waits until parallelism instances are running and returns an error if they aren't before the timeout, see
+// waitForPulsarConnectorRunning. Otherwise, a failure to get the status is only logged and a nil status is returned.
+func refreshPulsarConnectorStatus(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, cluster, kind, tenant, namespace, name string,
+	wait bool, parallelism int, previous *pulsarConnectorStatus, timeout time.Duration) (*pulsarConnectorStatus, error) {
+	if wait {
+		return waitForPulsarConnectorRunning(ctx, streamingClient, cluster, kind, tenant, namespace, name, parallelism, previous, timeout)
+	}
+	status, err := getPulsarConnectorStatus(ctx, streamingClient, cluster, kind, tenant, namespace, name)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("failed to get status of %s/%s/%s: %v", tenant, namespace, name, err))
+		return nil, nil
+	}
+	return status, nil
+}
+
+// pulsarInstanceStatusesSchema returns the computed attribute listing the status of each instance.
+func pulsarInstanceStatusesSchema() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: "Status of each instance, as last reported by the functions worker.",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"instance_id": schema.Int32Attribute{
+					Description: "ID of the instance.",
+					Computed:    true,
+				},
+				"running": schema.BoolAttribute{
+					Description: "Whether the instance is running.",
+					Computed:    true,
+				},
+				"error": schema.StringAttribute{
+					Description: "Error of the instance, if it failed.",
+					Computed:    true,
+				},
+				"num_restarts": schema.Int64Attribute{
+					Description: "Number of times the instance restarted.",
+					Computed:    true,
+				},
+				"worker_id": schema.StringAttribute{
+					Description: "ID of the functions worker running the instance.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// pulsarInstanceStatuses converts the status of the instances to a Terraform list. A nil status results in a null list.
+func pulsarInstanceStatuses(ctx context.Context, status *pulsarConnectorStatus) (types.List, diag.Diagnostics) {
+	elemType := types.ObjectType{AttrTypes: pulsarInstanceStatusAttrTypes}
+	if status == nil {
+		return types.ListNull(elemType), nil
+	}
+	instances := make([]pulsarInstanceStatusModel, 0, len(status.Instances))
+	for _, instance := range status.Instances {
+		instances = append(instances, pulsarInstanceStatusModel{
+			InstanceID:  types.Int32Value(int32(instance.InstanceID)),
+			Running:     types.BoolValue(instance.Status.Running),
+			Error:       types.StringValue(instance.Status.Error),
+			NumRestarts: types.Int64Value(instance.Status.NumRestarts),
+			WorkerID:    types.StringValue(instance.Status.WorkerID),
+		})
+	}
+	return types.ListValueFrom(ctx, elemType, instances)
+}
+
+// validateBuiltinConnector validates a "builtin://" archive, and the JSON configs if they are known, against the built-in
+// connectors of the cluster. The kind is "sinks" or "sources". Other archives are not validated, and the validation is
+// skipped if the connectors can't be retrieved.
+func validateBuiltinConnector(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, cluster, kind, archive string, configs types.String, configsPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	connectorName, ok := builtinConnectorName(archive)
+	if !ok || cluster == "" {
+		return diags
+	}
+
+	connectors, err := listBuiltinPulsarConnectors(ctx, streamingClient, cluster, kind)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("skipping validation of the archive: %v", err))
+		return diags
+	}
+	var names []string
+	found := false
+	for _, connector := range connectors {
+		names = append(names, connector.Name)
+		found = found || connector.Name == connectorName
+	}
+	if !found {
+		diags.AddAttributeError(
+			path.Root("archive"),
+			"unknown archive",
+			fmt.Sprintf("'%s' is not one of the built-in %s of cluster '%s', available %s are: %s", archive, kind, cluster, kind, strings.Join(names, ", ")))
+		return diags
+	}
+
+	if configs.IsUnknown() || configs.IsNull() {
+		return diags
+	}
+	var configValues map[string]interface{}
+	if err := json.Unmarshal([]byte(configs.ValueString()), &configValues); err != nil {
+		diags.AddAttributeError(
+			configsPath,
+			"invalid configs",
+			err.Error())
+		return diags
+	}
+	fields, err := getPulsarConnectorConfigDefinition(ctx, streamingClient, cluster, kind, connectorName)
+	if err != nil || len(fields) == 0 {
+		tflog.Warn(ctx, fmt.Sprintf("skipping validation of the configs: %v", err))
+		return diags
+	}
+	missing, unknown := validateConnectorConfigs(fields, configValues)
+	if len(missing) > 0 {
+		diags.AddAttributeError(
+			configsPath,
+			"missing required configs",
+			fmt.Sprintf("'%s' requires the following configs: %s", connectorName, strings.Join(missing, ", ")))
+	}
+	if len(unknown) > 0 {
+		diags.AddAttributeWarning(
+			configsPath,
+			"unknown configs",
+			fmt.Sprintf("the following configs are not known by '%s' and may be ignored: %s", connectorName, strings.Join(unknown, ", ")))
+	}
+	return diags
+}
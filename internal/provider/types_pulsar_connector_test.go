@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinConnectorName(t *testing.T) {
+	name, ok := builtinConnectorName("builtin://jdbc-clickhouse")
+	assert.True(t, ok)
+	assert.Equal(t, "jdbc-clickhouse", name)
+
+	_, ok = builtinConnectorName("builtin://")
+	assert.False(t, ok)
+
+	_, ok = builtinConnectorName("https://example.com/my-sink.nar")
+	assert.False(t, ok)
+}
+
+func TestValidateConnectorConfigs(t *testing.T) {
+	fields := []pulsarConfigFieldDefinition{
+		{FieldName: "jdbcUrl", Attributes: map[string]string{"required": "true"}},
+		{FieldName: "tableName", Attributes: map[string]string{"required": "true"}},
+		{FieldName: "batchSize", Attributes: map[string]string{"required": "true", "defaultValue": "200"}},
+		{FieldName: "userName", Attributes: map[string]string{"required": "false"}},
+	}
+
+	missing, unknown := validateConnectorConfigs(fields, map[string]interface{}{
+		"jdbcUrl":  "jdbc:clickhouse://localhost:8123/db",
+		"userName": "clickhouse",
+		"username": "clickhouse",
+	})
+	assert.Equal(t, []string{"tableName"}, missing)
+	assert.Equal(t, []string{"username"}, unknown)
+
+	missing, unknown = validateConnectorConfigs(fields, map[string]interface{}{
+		"jdbcUrl":   "jdbc:clickhouse://localhost:8123/db",
+		"tableName": "events",
+	})
+	assert.Empty(t, missing)
+	assert.Empty(t, unknown)
+}
+
+func TestRefreshPulsarConnectorResources(t *testing.T) {
+	cpu, actualCPU, actualRAM := 0.5, 1.0, int64(1073741824)
+	actual := &astrastreaming.Resources{Cpu: &actualCPU, Ram: &actualRAM}
+
+	assert.Nil(t, refreshPulsarConnectorResources(nil, actual))
+
+	refreshed := refreshPulsarConnectorResources(&pulsarConnectorResources{CPU: &cpu}, actual)
+	assert.Equal(t, actualCPU, *refreshed.CPU)
+	// the RAM is not configured, its default value is ignored
+	assert.Nil(t, refreshed.RAM)
+	assert.Nil(t, refreshed.Disk)
+}
+
+func TestPulsarInstanceStatuses(t *testing.T) {
+	instances, diags := pulsarInstanceStatuses(context.Background(), nil)
+	assert.False(t, diags.HasError())
+	assert.True(t, instances.IsNull())
+
+	status := &pulsarConnectorStatus{NumInstances: 1, Instances: make([]pulsarConnectorInstanceStatus, 1)}
+	status.Instances[0].Status.Error = "crash loop"
+	instances, diags = pulsarInstanceStatuses(context.Background(), status)
+	assert.False(t, diags.HasError())
+	assert.Len(t, instances.Elements(), 1)
+}
+
+func TestPulsarImportedInputs(t *testing.T) {
+	pattern := "persistent://my-tenant/default/orders-.*"
+	inputs, topicsPattern := pulsarImportedInputs(nil, &pattern, nil)
+	assert.Nil(t, inputs)
+	assert.Equal(t, pattern, *topicsPattern)
+
+	regex := true
+	inputs, topicsPattern = pulsarImportedInputs(nil, nil, map[string]astrastreaming.ConsumerConfig{
+		pattern: {RegexPattern: &regex},
+	})
+	assert.Nil(t, inputs)
+	assert.Equal(t, pattern, *topicsPattern)
+
+	inputs, topicsPattern = pulsarImportedInputs([]string{"persistent://my-tenant/default/b"}, nil, map[string]astrastreaming.ConsumerConfig{
+		"persistent://my-tenant/default/b": {},
+		"persistent://my-tenant/default/a": {},
+	})
+	assert.Equal(t, []string{"persistent://my-tenant/default/a", "persistent://my-tenant/default/b"}, inputs)
+	assert.Nil(t, topicsPattern)
+
+	inputs, topicsPattern = pulsarImportedInputs(nil, nil, nil)
+	assert.Nil(t, inputs)
+	assert.Nil(t, topicsPattern)
+}
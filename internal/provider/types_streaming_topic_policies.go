@@ -0,0 +1,380 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/datastax/pulsar-admin-client-go/src/pulsaradmin"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	// String constants for the Terraform schema of Pulsar topic policies.  The policies shared with
+	// namespaces reuse the namespace policy constants.
+	policyMaxProducers                      = "max_producers"
+	policyMaxConsumers                      = "max_consumers"
+	policyDeduplicationEnabled              = "deduplication_enabled"
+	policyPublishRate                       = "publish_rate"
+	policyPublishRateInMsg                  = "publish_throttling_rate_in_msg"
+	policyPublishRateInByte                 = "publish_throttling_rate_in_byte"
+	policyDispatchRate                      = "dispatch_rate"
+	policyDispatchRateInMsg                 = "dispatch_throttling_rate_in_msg"
+	policyDispatchRateInByte                = "dispatch_throttling_rate_in_byte"
+	policyDispatchRatePeriodInSecond        = "rate_period_in_second"
+	policyDispatchRateRelativeToPublishRate = "relative_to_publish_rate"
+	policyDelayedDelivery                   = "delayed_delivery"
+	policyDelayedDeliveryActive             = "active"
+	policyDelayedDeliveryTickTimeMillis     = "tick_time_millis"
+)
+
+// PulsarTopicPolicies are the topic level overrides of the namespace policies.  The JSON tags match the
+// payloads of the Pulsar admin topic policy endpoints.
+type PulsarTopicPolicies struct {
+	MessageTTLInSeconds  *int32                                  `tfsdk:"message_ttl_in_seconds" json:"messageTTLInSeconds,omitempty"`
+	MaxProducers         *int32                                  `tfsdk:"max_producers" json:"maxProducers,omitempty"`
+	MaxConsumers         *int32                                  `tfsdk:"max_consumers" json:"maxConsumers,omitempty"`
+	DeduplicationEnabled *bool                                   `tfsdk:"deduplication_enabled" json:"deduplicationEnabled,omitempty"`
+	RetentionPolicies    *PulsarNamespaceRetentionPolicies       `tfsdk:"retention_policies" json:"retentionPolicies,omitempty"`
+	BacklogQuota         map[string]*PulsarNamespaceBacklogQuota `tfsdk:"backlog_quota_map" json:"backLogQuotaMap,omitempty"`
+	PublishRate          *PulsarTopicPublishRate                 `tfsdk:"publish_rate" json:"publishRate,omitempty"`
+	DispatchRate         *PulsarTopicDispatchRate                `tfsdk:"dispatch_rate" json:"dispatchRate,omitempty"`
+	DelayedDelivery      *PulsarTopicDelayedDeliveryPolicies     `tfsdk:"delayed_delivery" json:"delayedDeliveryPolicies,omitempty"`
+}
+
+type PulsarTopicPublishRate struct {
+	PublishThrottlingRateInMsg  *int32 `tfsdk:"publish_throttling_rate_in_msg" json:"publishThrottlingRateInMsg,omitempty"`
+	PublishThrottlingRateInByte *int64 `tfsdk:"publish_throttling_rate_in_byte" json:"publishThrottlingRateInByte,omitempty"`
+}
+
+type PulsarTopicDispatchRate struct {
+	DispatchThrottlingRateInMsg  *int32 `tfsdk:"dispatch_throttling_rate_in_msg" json:"dispatchThrottlingRateInMsg,omitempty"`
+	DispatchThrottlingRateInByte *int64 `tfsdk:"dispatch_throttling_rate_in_byte" json:"dispatchThrottlingRateInByte,omitempty"`
+	RatePeriodInSecond           *int32 `tfsdk:"rate_period_in_second" json:"ratePeriodInSecond,omitempty"`
+	RelativeToPublishRate        *bool  `tfsdk:"relative_to_publish_rate" json:"relativeToPublishRate,omitempty"`
+}
+
+type PulsarTopicDelayedDeliveryPolicies struct {
+	Active         *bool  `tfsdk:"active" json:"active,omitempty"`
+	TickTimeMillis *int64 `tfsdk:"tick_time_millis" json:"tickTime,omitempty"`
+}
+
+var (
+	// Schema definition for Pulsar topic policies
+	pulsarTopicPoliciesSchema = schema.SingleNestedAttribute{
+		Description: "Policies to be applied to the Pulsar topic, overriding the policies of its namespace. Policies which are not set " +
+			"are read from the namespace or broker defaults. Only supported for persistent topics. For more details related to valid policy " +
+			"configuration, refer to the Pulsar topic policies documentation (https://pulsar.apache.org/docs/3.0.x/admin-api-topics/).",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			policyMessageTTLInSeconds:  int64PulsarNamespacePolicyAttribute,
+			policyMaxProducers:         int64PulsarNamespacePolicyAttribute,
+			policyMaxConsumers:         int64PulsarNamespacePolicyAttribute,
+			policyDeduplicationEnabled: boolPulsarNamespacePolicyAttribute,
+			policyRetentionPolicies:    pulsarNamespacePoliciesSchema.Attributes[policyRetentionPolicies],
+			policyBacklogQuotaMap:      pulsarNamespacePoliciesSchema.Attributes[policyBacklogQuotaMap],
+			policyPublishRate: schema.SingleNestedAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					policyPublishRateInMsg:  int64PulsarNamespacePolicyAttribute,
+					policyPublishRateInByte: int64PulsarNamespacePolicyAttribute,
+				},
+			},
+			policyDispatchRate: schema.SingleNestedAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					policyDispatchRateInMsg:                 int64PulsarNamespacePolicyAttribute,
+					policyDispatchRateInByte:                int64PulsarNamespacePolicyAttribute,
+					policyDispatchRatePeriodInSecond:        int64PulsarNamespacePolicyAttribute,
+					policyDispatchRateRelativeToPublishRate: boolPulsarNamespacePolicyAttribute,
+				},
+			},
+			policyDelayedDelivery: schema.SingleNestedAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					policyDelayedDeliveryActive:         boolPulsarNamespacePolicyAttribute,
+					policyDelayedDeliveryTickTimeMillis: int64PulsarNamespacePolicyAttribute,
+				},
+			},
+		},
+	}
+
+	pulsarTopicPoliciesAttributeTypes = pulsarTopicPoliciesSchema.GetType().(types.ObjectType).AttributeTypes()
+)
+
+// pulsarTopicPoliciesObjectToStruct converts from a Terraform object into a Pulsar topic policies struct
+func pulsarTopicPoliciesObjectToStruct(ctx context.Context, policyObj types.Object) (*PulsarTopicPolicies, diag.Diagnostics) {
+	objOptions := basetypes.ObjectAsOptions{
+		UnhandledUnknownAsEmpty: true,
+	}
+	policies := &PulsarTopicPolicies{}
+	diags := policyObj.As(ctx, policies, objOptions)
+	return policies, diags
+}
+
+func pulsarTopicPolicyError(policy string) string {
+	return fmt.Sprintf("Error setting topic policy '%v', namespace values will be used.  Perform a `terraform apply --refresh-only` view the diff", policy)
+}
+
+// pulsarTopicPolicyBody encodes the body of a topic policy request.  The policies are numbers, booleans
+// and structs of them, which always encode successfully.
+func pulsarTopicPolicyBody(value any) io.Reader {
+	data, _ := json.Marshal(value)
+	return bytes.NewReader(data)
+}
+
+// readPulsarTopicPolicy decodes the response of a topic policy endpoint into value, which is left
+// unchanged if the policy is not set.
+func readPulsarTopicPolicy(resp *http.Response, err error, policy string, value any) diag.Diagnostics {
+	diags := HTTPResponseDiagErr(resp, err, fmt.Sprintf("failed to get topic policy '%s'", policy))
+	if diags.HasError() {
+		return diags
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to read topic policy '%s'", policy), err.Error())
+		return diags
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return diags
+	}
+	if err := json.Unmarshal(body, value); err != nil {
+		diags.AddError(fmt.Sprintf("failed to decode topic policy '%s'", policy), err.Error())
+	}
+	return diags
+}
+
+// getPulsarTopicPolicies reads the policies applied to a topic, which includes the values inherited from the
+// namespace and broker when the topic does not override them.
+func getPulsarTopicPolicies(ctx context.Context, client *pulsaradmin.ClientWithResponses, tenant, namespace, topic string, requestEditors ...pulsaradmin.RequestEditorFn) (types.Object, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	applied := true
+	policies := &PulsarTopicPolicies{}
+
+	resp, err := client.PersistentTopicsGetMessageTTL(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetMessageTTLParams{Applied: &applied}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyMessageTTLInSeconds, &policies.MessageTTLInSeconds)...)
+	resp, err = client.PersistentTopicsGetMaxProducers(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetMaxProducersParams{Applied: &applied}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyMaxProducers, &policies.MaxProducers)...)
+	resp, err = client.PersistentTopicsGetMaxConsumers(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetMaxConsumersParams{Applied: &applied}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyMaxConsumers, &policies.MaxConsumers)...)
+	resp, err = client.PersistentTopicsGetDeduplication(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetDeduplicationParams{Applied: &applied}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyDeduplicationEnabled, &policies.DeduplicationEnabled)...)
+	resp, err = client.PersistentTopicsGetRetention(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetRetentionParams{Applied: &applied}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyRetentionPolicies, &policies.RetentionPolicies)...)
+	resp, err = client.PersistentTopicsGetBacklogQuotaMap(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetBacklogQuotaMapParams{Applied: &applied}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyBacklogQuotaMap, &policies.BacklogQuota)...)
+	// the publish rate has no applied value, only the topic level one can be read
+	resp, err = client.PersistentTopicsGetPublishRate(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetPublishRateParams{}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyPublishRate, &policies.PublishRate)...)
+	resp, err = client.PersistentTopicsGetDispatchRate(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetDispatchRateParams{Applied: &applied}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyDispatchRate, &policies.DispatchRate)...)
+	resp, err = client.PersistentTopicsGetDelayedDeliveryPolicies(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsGetDelayedDeliveryPoliciesParams{Applied: &applied}, requestEditors...)
+	diags.Append(readPulsarTopicPolicy(resp, err, policyDelayedDelivery, &policies.DelayedDelivery)...)
+	if diags.HasError() {
+		return types.ObjectNull(pulsarTopicPoliciesAttributeTypes), diags
+	}
+
+	policiesObj, objDiags := types.ObjectValueFrom(ctx, pulsarTopicPoliciesAttributeTypes, policies)
+	diags.Append(objDiags...)
+	return policiesObj, diags
+}
+
+// setTopicPolicies calls the endpoints for the topic policy values which are set in the plan
+func setTopicPolicies(ctx context.Context, client *pulsaradmin.ClientWithResponses, tenant, namespace, topic string, policiesObj types.Object, requestEditors ...pulsaradmin.RequestEditorFn) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if policiesObj.IsNull() || policiesObj.IsUnknown() {
+		return diags
+	}
+	policies, policyDiags := pulsarTopicPoliciesObjectToStruct(ctx, policiesObj)
+	diags.Append(policyDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if policies.MessageTTLInSeconds != nil {
+		params := pulsaradmin.PersistentTopicsSetMessageTTLParams{MessageTTL: *policies.MessageTTLInSeconds}
+		resp, err := client.PersistentTopicsSetMessageTTL(ctx, tenant, namespace, topic, &params, requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyMessageTTLInSeconds))...)
+	}
+	if policies.MaxProducers != nil {
+		resp, err := client.PersistentTopicsSetMaxProducersWithBody(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsSetMaxProducersParams{},
+			"application/json", pulsarTopicPolicyBody(*policies.MaxProducers), requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyMaxProducers))...)
+	}
+	if policies.MaxConsumers != nil {
+		resp, err := client.PersistentTopicsSetMaxConsumersWithBody(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsSetMaxConsumersParams{},
+			"application/json", pulsarTopicPolicyBody(*policies.MaxConsumers), requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyMaxConsumers))...)
+	}
+	if policies.DeduplicationEnabled != nil {
+		resp, err := client.PersistentTopicsSetDeduplicationWithBody(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsSetDeduplicationParams{},
+			"application/json", pulsarTopicPolicyBody(*policies.DeduplicationEnabled), requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyDeduplicationEnabled))...)
+	}
+	if policies.RetentionPolicies != nil {
+		resp, err := client.PersistentTopicsSetRetentionWithBody(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsSetRetentionParams{},
+			"application/json", pulsarTopicPolicyBody(policies.RetentionPolicies), requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyRetentionPolicies))...)
+	}
+	for quotaTypeName, quota := range policies.BacklogQuota {
+		quotaType := (pulsaradmin.PersistentTopicsSetBacklogQuotaParamsBacklogQuotaType)(quotaTypeName)
+		params := pulsaradmin.PersistentTopicsSetBacklogQuotaParams{BacklogQuotaType: &quotaType}
+		resp, err := client.PersistentTopicsSetBacklogQuotaWithBody(ctx, tenant, namespace, topic, &params,
+			"application/json", pulsarTopicPolicyBody(quota), requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyBacklogQuotaMap))...)
+	}
+	if policies.PublishRate != nil {
+		resp, err := client.PersistentTopicsSetPublishRateWithBody(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsSetPublishRateParams{},
+			"application/json", pulsarTopicPolicyBody(policies.PublishRate), requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyPublishRate))...)
+	}
+	if policies.DispatchRate != nil {
+		resp, err := client.PersistentTopicsSetDispatchRateWithBody(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsSetDispatchRateParams{},
+			"application/json", pulsarTopicPolicyBody(policies.DispatchRate), requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyDispatchRate))...)
+	}
+	if policies.DelayedDelivery != nil {
+		resp, err := client.PersistentTopicsSetDelayedDeliveryPoliciesWithBody(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsSetDelayedDeliveryPoliciesParams{},
+			"application/json", pulsarTopicPolicyBody(policies.DelayedDelivery), requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, pulsarTopicPolicyError(policyDelayedDelivery))...)
+	}
+	return diags
+}
+
+// removeTopicPolicies removes all the topic level policies, so that the topic falls back to the namespace policies
+func removeTopicPolicies(ctx context.Context, client *pulsaradmin.ClientWithResponses, tenant, namespace, topic string, requestEditors ...pulsaradmin.RequestEditorFn) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	removeError := func(policy string) string {
+		return fmt.Sprintf("Error removing topic policy '%v'", policy)
+	}
+
+	resp, err := client.PersistentTopicsRemoveMessageTTL(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsRemoveMessageTTLParams{}, requestEditors...)
+	diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyMessageTTLInSeconds))...)
+	resp, err = client.PersistentTopicsRemoveMaxProducers(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsRemoveMaxProducersParams{}, requestEditors...)
+	diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyMaxProducers))...)
+	resp, err = client.PersistentTopicsRemoveMaxConsumers(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsRemoveMaxConsumersParams{}, requestEditors...)
+	diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyMaxConsumers))...)
+	resp, err = client.PersistentTopicsRemoveDeduplication(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsRemoveDeduplicationParams{}, requestEditors...)
+	diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyDeduplicationEnabled))...)
+	resp, err = client.PersistentTopicsRemoveRetention(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsRemoveRetentionParams{}, requestEditors...)
+	diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyRetentionPolicies))...)
+	for _, quotaType := range []pulsaradmin.PersistentTopicsRemoveBacklogQuotaParamsBacklogQuotaType{
+		pulsaradmin.PersistentTopicsRemoveBacklogQuotaParamsBacklogQuotaTypeDestinationStorage,
+		pulsaradmin.PersistentTopicsRemoveBacklogQuotaParamsBacklogQuotaTypeMessageAge,
+	} {
+		resp, err = client.PersistentTopicsRemoveBacklogQuota(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsRemoveBacklogQuotaParams{BacklogQuotaType: &quotaType}, requestEditors...)
+		diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyBacklogQuotaMap))...)
+	}
+	resp, err = client.PersistentTopicsRemovePublishRate(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsRemovePublishRateParams{}, requestEditors...)
+	diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyPublishRate))...)
+	resp, err = client.PersistentTopicsRemoveDispatchRate(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsRemoveDispatchRateParams{}, requestEditors...)
+	diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyDispatchRate))...)
+	resp, err = client.PersistentTopicsDeleteDelayedDeliveryPolicies(ctx, tenant, namespace, topic, &pulsaradmin.PersistentTopicsDeleteDelayedDeliveryPoliciesParams{}, requestEditors...)
+	diags.Append(HTTPResponseDiagWarn(resp, err, removeError(policyDelayedDelivery))...)
+	return diags
+}
+
+// applyTopicPolicies sets the planned topic policies and returns them merged with the policies applied by the server
+func applyTopicPolicies(ctx context.Context, client *pulsaradmin.ClientWithResponses, tenant, namespace, topic string, policiesObj types.Object, requestEditors ...pulsaradmin.RequestEditorFn) (types.Object, diag.Diagnostics) {
+	diags := setTopicPolicies(ctx, client, tenant, namespace, topic, policiesObj, requestEditors...)
+	if diags.HasError() {
+		return policiesObj, diags
+	}
+
+	policiesFromServer, getDiags := getPulsarTopicPolicies(ctx, client, tenant, namespace, topic, requestEditors...)
+	diags.Append(getDiags...)
+	if diags.HasError() {
+		return policiesObj, diags
+	}
+	mergedPolicies, mergeDiags := mergePulsarTopicPolicies(ctx, policiesObj, policiesFromServer, pulsarTopicPoliciesAttributeTypes)
+	diags.Append(mergeDiags...)
+	return mergedPolicies, diags
+}
+
+// mergePulsarTopicPolicies replaces the null or unknown planned policies with the policies read from the server, like
+// MergeTerraformObjects.  Pulsar applies topic policies asynchronously and has no value for the policies which are not
+// set, so the unknown attributes of a policy without a value on the server are resolved to null, and a fully known
+// configured map is kept rather than replaced by the value read from the server.
+func mergePulsarTopicPolicies(ctx context.Context, planned, fromServer types.Object, attributeTypes map[string]attr.Type) (types.Object, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	if fromServer.IsNull() || fromServer.IsUnknown() {
+		if planned.IsNull() || planned.IsUnknown() {
+			return types.ObjectNull(attributeTypes), diags
+		}
+	} else if planned.IsNull() || planned.IsUnknown() {
+		return basetypes.NewObjectValue(attributeTypes, fromServer.Attributes())
+	}
+	plannedAttributes := planned.Attributes()
+	serverAttributes := fromServer.Attributes()
+	attributes := map[string]attr.Value{}
+	for name, attrType := range attributeTypes {
+		serverValue, ok := serverAttributes[name]
+		if !ok {
+			nullValue, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), nil))
+			if err != nil {
+				diags.AddError("Failed to merge topic policies", fmt.Sprintf("Failed to create null value for policy %s: %v", name, err))
+				return planned, diags
+			}
+			serverValue = nullValue
+		}
+
+		plannedValue, ok := plannedAttributes[name]
+		if !ok || plannedValue.IsNull() || plannedValue.IsUnknown() {
+			attributes[name] = serverValue
+			continue
+		}
+
+		switch plannedValue := plannedValue.(type) {
+		case types.Object:
+			serverObjValue, ok := serverValue.(types.Object)
+			objType, typeOk := attrType.(types.ObjectType)
+			if !ok || !typeOk {
+				diags.AddWarning("Non matching types for policy: "+name, "No type information found when merging topic policies")
+				attributes[name] = plannedValue
+				continue
+			}
+			mergedValue, mergeDiags := mergePulsarTopicPolicies(ctx, plannedValue, serverObjValue, objType.AttributeTypes())
+			diags.Append(mergeDiags...)
+			if diags.HasError() {
+				return planned, diags
+			}
+			attributes[name] = mergedValue
+		case types.Map:
+			// a fully known map was configured and is kept as is
+			if tfValue, err := plannedValue.ToTerraformValue(ctx); err == nil && tfValue.IsFullyKnown() {
+				attributes[name] = plannedValue
+			} else {
+				attributes[name] = serverValue
+			}
+		default:
+			attributes[name] = plannedValue
+		}
+	}
+
+	return basetypes.NewObjectValue(attributeTypes, attributes)
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datastax/pulsar-admin-client-go/src/pulsaradmin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTopicPolicies(t *testing.T) {
+	topicPath := "/admin/v2/persistent/my-tenant/default/my-topic/"
+	appliedPolicies := map[string]string{
+		"messageTTL":           `3600`,
+		"maxProducers":         ``,
+		"maxConsumers":         `10`,
+		"deduplicationEnabled": `false`,
+		"retention":            `{"retentionTimeInMinutes": 60, "retentionSizeInMB": 100}`,
+		"backlogQuotaMap":      `{"destination_storage": {"limitSize": -1, "limitTime": -1, "policy": "producer_request_hold"}}`,
+		"publishRate":          `null`,
+		"dispatchRate":         `{"dispatchThrottlingRateInMsg": -1, "dispatchThrottlingRateInByte": -1, "ratePeriodInSecond": 1, "relativeToPublishRate": false}`,
+		"delayedDelivery":      `{"active": true, "tickTime": 1000}`,
+	}
+	setPolicies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "pulsar-gcp-useast1", r.Header.Get(pulsarClusterHeader))
+		policy := strings.TrimPrefix(r.URL.Path, topicPath)
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, policy != "publishRate", r.URL.Query().Get("applied") == "true")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(appliedPolicies[policy]))
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			setPolicies[policy] = string(body) + r.URL.RawQuery
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := pulsaradmin.NewClientWithResponses(server.URL + "/admin/v2")
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	policies, diags := types.ObjectValue(pulsarTopicPoliciesAttributeTypes, map[string]attr.Value{
+		policyMessageTTLInSeconds:  types.Int64Unknown(),
+		policyMaxProducers:         types.Int64Value(5),
+		policyMaxConsumers:         types.Int64Unknown(),
+		policyDeduplicationEnabled: types.BoolValue(true),
+		policyRetentionPolicies:    types.ObjectUnknown(pulsarTopicPoliciesAttributeTypes[policyRetentionPolicies].(types.ObjectType).AttrTypes),
+		policyBacklogQuotaMap:      types.MapUnknown(pulsarTopicPoliciesAttributeTypes[policyBacklogQuotaMap].(types.MapType).ElemType),
+		policyPublishRate: types.ObjectValueMust(pulsarTopicPoliciesAttributeTypes[policyPublishRate].(types.ObjectType).AttrTypes, map[string]attr.Value{
+			policyPublishRateInMsg:  types.Int64Value(100),
+			policyPublishRateInByte: types.Int64Unknown(),
+		}),
+		policyDispatchRate:    types.ObjectUnknown(pulsarTopicPoliciesAttributeTypes[policyDispatchRate].(types.ObjectType).AttrTypes),
+		policyDelayedDelivery: types.ObjectUnknown(pulsarTopicPoliciesAttributeTypes[policyDelayedDelivery].(types.ObjectType).AttrTypes),
+	})
+	assert.False(t, diags.HasError())
+
+	policies, diags = applyTopicPolicies(ctx, client, "my-tenant", "default", "my-topic", policies, setPulsarClusterHeaders("pulsar-gcp-useast1"))
+	assert.False(t, diags.HasError(), diags)
+
+	// only the configured policies are set on the topic
+	assert.Equal(t, map[string]string{
+		"maxProducers":         `5`,
+		"deduplicationEnabled": `true`,
+		"publishRate":          `{"publishThrottlingRateInMsg":100}`,
+	}, setPolicies)
+
+	// the other policies are read from the policies applied to the topic
+	result, diags := pulsarTopicPoliciesObjectToStruct(ctx, policies)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, int32(3600), *result.MessageTTLInSeconds)
+	assert.Equal(t, int32(5), *result.MaxProducers)
+	assert.Equal(t, int32(10), *result.MaxConsumers)
+	assert.True(t, *result.DeduplicationEnabled)
+	assert.Equal(t, int32(60), *result.RetentionPolicies.RetentionTimeInMinutes)
+	assert.Equal(t, "producer_request_hold", *result.BacklogQuota["destination_storage"].Policy)
+	assert.Equal(t, int32(100), *result.PublishRate.PublishThrottlingRateInMsg)
+	assert.Nil(t, result.PublishRate.PublishThrottlingRateInByte)
+	assert.Equal(t, int32(1), *result.DispatchRate.RatePeriodInSecond)
+	assert.Equal(t, int64(1000), *result.DelayedDelivery.TickTimeMillis)
+}
+
+func TestMergePulsarTopicPolicies(t *testing.T) {
+	ctx := context.Background()
+	attributeTypes := map[string]attr.Type{
+		"foo": types.StringType,
+		"bar": types.Int64Type,
+		"baz": types.MapType{ElemType: types.StringType},
+	}
+
+	planned := types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+		"foo": types.StringValue("foo-planned"),
+		"bar": types.Int64Unknown(),
+		"baz": types.MapValueMust(types.StringType, map[string]attr.Value{"a": types.StringValue("planned")}),
+	})
+	fromServer := types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+		"foo": types.StringValue("foo-server"),
+		"bar": types.Int64Value(32),
+		"baz": types.MapValueMust(types.StringType, map[string]attr.Value{"a": types.StringValue("server")}),
+	})
+
+	// the planned values are kept, including fully known maps the server hasn't applied yet
+	merged, diags := mergePulsarTopicPolicies(ctx, planned, fromServer, attributeTypes)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "foo-planned", merged.Attributes()["foo"].(types.String).ValueString())
+	assert.Equal(t, int64(32), merged.Attributes()["bar"].(types.Int64).ValueInt64())
+	assert.Equal(t, "\"planned\"", merged.Attributes()["baz"].(types.Map).Elements()["a"].String())
+
+	// unknown values are resolved to null when the server has no value for the policy
+	merged, diags = mergePulsarTopicPolicies(ctx, planned, types.ObjectNull(attributeTypes), attributeTypes)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "foo-planned", merged.Attributes()["foo"].(types.String).ValueString())
+	assert.True(t, merged.Attributes()["bar"].IsNull())
+
+	merged, diags = mergePulsarTopicPolicies(ctx, types.ObjectUnknown(attributeTypes), types.ObjectNull(attributeTypes), attributeTypes)
+	assert.False(t, diags.HasError())
+	assert.True(t, merged.IsNull())
+}
@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// dataAPIClient sends commands to the Data API of a database. The Data API is not covered by the generated clients, so
+// the commands and their responses are modelled here.
+type dataAPIClient struct {
+	endpoint        string
+	httpClient      *http.Client
+	providerVersion string
+	userAgent       string
+}
+
+func newDataAPIClient(dbid, providerVersion, userAgent, region string) *dataAPIClient {
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = 10
+	retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		// Data API commands are all sent with POST, so only retry on errors which guarantee the command was not processed
+		if err != nil || resp == nil {
+			return false, err
+		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable || resp.StatusCode == http.StatusBadGateway {
+			return true, nil
+		}
+		return false, nil
+	}
+
+	return &dataAPIClient{
+		endpoint:        fmt.Sprintf("https://%s-%s.%s/api/json/v1", dbid, region, astraAppsDomain),
+		httpClient:      retryClient.StandardClient(),
+		providerVersion: providerVersion,
+		userAgent:       userAgent,
+	}
+}
+
+// dataAPIResponse is the generic response of a Data API command.
+type dataAPIResponse struct {
+	Status map[string]json.RawMessage `json:"status,omitempty"`
+	Errors []dataAPIError             `json:"errors,omitempty"`
+}
+
+type dataAPIError struct {
+	Message   string `json:"message"`
+	ErrorCode string `json:"errorCode,omitempty"`
+}
+
+func (e dataAPIError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("%s: %s", e.ErrorCode, e.Message)
+	}
+	return e.Message
+}
+
+// dataAPICommandError is returned when the Data API processed a command but reported errors.
+type dataAPICommandError struct {
+	Command string
+	Errors  []dataAPIError
+}
+
+func (e *dataAPICommandError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("data API command %s failed: %s", e.Command, strings.Join(messages, "; "))
+}
+
+// hasErrorCode returns true if any of the errors reported by the Data API has the given code.
+func (e *dataAPICommandError) hasErrorCode(code string) bool {
+	for _, err := range e.Errors {
+		if err.ErrorCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// runCommand sends a single command to the given path, which is either empty (database level commands), a keyspace, or
+// keyspace/collection.
+func (c *dataAPIClient) runCommand(ctx context.Context, token, path, command string, body interface{}) (*dataAPIResponse, error) {
+	payload, err := json.Marshal(map[string]interface{}{command: body})
+	if err != nil {
+		return nil, err
+	}
+
+	url := c.endpoint
+	if path != "" {
+		url += "/" + path
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", token)
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("X-Astra-Provider-Version", c.providerVersion)
+	req.Header.Set("X-Astra-Client-Version", fmt.Sprintf("go/%s", astra.Version))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending data API command %s: %w", command, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading data API response to %s: %w", command, err)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("data API command %s failed, status code: %d, message: %s", command, resp.StatusCode, string(respBody))
+	}
+
+	var result dataAPIResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data API response to %s: %w", command, err)
+	}
+	if len(result.Errors) > 0 {
+		return nil, &dataAPICommandError{Command: command, Errors: result.Errors}
+	}
+	return &result, nil
+}
+
+// dataAPICollection is a collection definition, as used by the createCollection and findCollections commands.
+type dataAPICollection struct {
+	Name    string                   `json:"name"`
+	Options dataAPICollectionOptions `json:"options"`
+}
+
+type dataAPICollectionOptions struct {
+	Vector    *dataAPICollectionVector    `json:"vector,omitempty"`
+	Indexing  *dataAPICollectionIndexing  `json:"indexing,omitempty"`
+	DefaultID *dataAPICollectionDefaultID `json:"defaultId,omitempty"`
+}
+
+type dataAPICollectionVector struct {
+	Dimension *int64                `json:"dimension,omitempty"`
+	Metric    string                `json:"metric,omitempty"`
+	Service   *dataAPIVectorService `json:"service,omitempty"`
+}
+
+// dataAPIVectorService configures the embedding provider used to generate vectors server side (vectorize).
+type dataAPIVectorService struct {
+	Provider       string                 `json:"provider"`
+	ModelName      string                 `json:"modelName,omitempty"`
+	Authentication map[string]string      `json:"authentication,omitempty"`
+	Parameters     map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type dataAPICollectionIndexing struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+type dataAPICollectionDefaultID struct {
+	Type string `json:"type"`
+}
+
+func (c *dataAPIClient) createCollection(ctx context.Context, token, keyspace string, collection dataAPICollection) error {
+	_, err := c.runCommand(ctx, token, keyspace, "createCollection", collection)
+	return err
+}
+
+// findCollection returns the definition of the given collection, or nil if it doesn't exist.
+func (c *dataAPIClient) findCollection(ctx context.Context, token, keyspace, name string) (*dataAPICollection, error) {
+	resp, err := c.runCommand(ctx, token, keyspace, "findCollections", map[string]interface{}{
+		"options": map[string]bool{"explain": true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var collections []dataAPICollection
+	if raw, ok := resp.Status["collections"]; ok {
+		if err := json.Unmarshal(raw, &collections); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal collections of keyspace %s: %w", keyspace, err)
+		}
+	}
+	for _, collection := range collections {
+		if collection.Name == name {
+			return &collection, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *dataAPIClient) deleteCollection(ctx context.Context, token, keyspace, name string) error {
+	_, err := c.runCommand(ctx, token, keyspace, "deleteCollection", map[string]string{"name": name})
+	return err
+}
+
+// dataAPIEmbeddingProvider is an entry of the embedding provider catalog returned by the findEmbeddingProviders command.
+type dataAPIEmbeddingProvider struct {
+	DisplayName             string                                            `json:"displayName"`
+	SupportedAuthentication map[string]dataAPIEmbeddingProviderAuthentication `json:"supportedAuthentication"`
+	Parameters              []dataAPIEmbeddingProviderParameter               `json:"parameters"`
+	Models                  []dataAPIEmbeddingProviderModel                   `json:"models"`
+}
+
+type dataAPIEmbeddingProviderAuthentication struct {
+	Enabled bool                            `json:"enabled"`
+	Tokens  []dataAPIEmbeddingProviderToken `json:"tokens"`
+}
+
+type dataAPIEmbeddingProviderToken struct {
+	Accepted  string `json:"accepted"`
+	Forwarded string `json:"forwarded"`
+}
+
+type dataAPIEmbeddingProviderParameter struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Required     bool   `json:"required"`
+	DefaultValue string `json:"defaultValue"`
+}
+
+type dataAPIEmbeddingProviderModel struct {
+	Name            string                              `json:"name"`
+	VectorDimension *int64                              `json:"vectorDimension"`
+	Parameters      []dataAPIEmbeddingProviderParameter `json:"parameters"`
+}
+
+// findEmbeddingProviders returns the catalog of embedding providers supported by the database, keyed by provider name.
+func (c *dataAPIClient) findEmbeddingProviders(ctx context.Context, token string) (map[string]dataAPIEmbeddingProvider, error) {
+	resp, err := c.runCommand(ctx, token, "", "findEmbeddingProviders", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	providers := map[string]dataAPIEmbeddingProvider{}
+	if raw, ok := resp.Status["embeddingProviders"]; ok {
+		if err := json.Unmarshal(raw, &providers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal embedding providers: %w", err)
+		}
+	}
+	return providers, nil
+}
+
+// dataAPITable is a table definition, as used by the createTable and listTables commands.
+type dataAPITable struct {
+	Name       string                 `json:"name"`
+	Definition dataAPITableDefinition `json:"definition"`
+}
+
+type dataAPITableDefinition struct {
+	Columns    map[string]dataAPITableColumn `json:"columns"`
+	PrimaryKey dataAPITablePrimaryKey        `json:"primaryKey"`
+}
+
+type dataAPITableColumn struct {
+	Type      string                `json:"type"`
+	KeyType   string                `json:"keyType,omitempty"`
+	ValueType string                `json:"valueType,omitempty"`
+	Dimension *int64                `json:"dimension,omitempty"`
+	Service   *dataAPIVectorService `json:"service,omitempty"`
+}
+
+type dataAPITablePrimaryKey struct {
+	PartitionBy   []string                  `json:"partitionBy"`
+	PartitionSort dataAPITablePartitionSort `json:"partitionSort,omitempty"`
+}
+
+// dataAPITablePartitionSort is the ordered list of clustering columns of a table. The Data API represents it as a JSON
+// object, where the order of the keys is significant, so it is (un)marshalled by hand.
+type dataAPITablePartitionSort []dataAPITableSortColumn
+
+type dataAPITableSortColumn struct {
+	Column    string
+	Ascending bool
+}
+
+func (s dataAPITablePartitionSort) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, c := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(c.Column)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		if c.Ascending {
+			buf.WriteString(":1")
+		} else {
+			buf.WriteString(":-1")
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (s *dataAPITablePartitionSort) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		*s = nil
+		return nil
+	} else if token != json.Delim('{') {
+		return fmt.Errorf("expected partitionSort to be an object, got %v", token)
+	}
+
+	columns := dataAPITablePartitionSort{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		column, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("unexpected partitionSort key %v", token)
+		}
+		var order int
+		if err := decoder.Decode(&order); err != nil {
+			return fmt.Errorf("invalid order of partitionSort column %s: %w", column, err)
+		}
+		columns = append(columns, dataAPITableSortColumn{Column: column, Ascending: order >= 0})
+	}
+	*s = columns
+	return nil
+}
+
+func (c *dataAPIClient) createTable(ctx context.Context, token, keyspace string, table dataAPITable) error {
+	_, err := c.runCommand(ctx, token, keyspace, "createTable", table)
+	return err
+}
+
+// findTable returns the definition of the given table, or nil if it doesn't exist.
+func (c *dataAPIClient) findTable(ctx context.Context, token, keyspace, name string) (*dataAPITable, error) {
+	resp, err := c.runCommand(ctx, token, keyspace, "listTables", map[string]interface{}{
+		"options": map[string]bool{"explain": true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []dataAPITable
+	if raw, ok := resp.Status["tables"]; ok {
+		if err := json.Unmarshal(raw, &tables); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tables of keyspace %s: %w", keyspace, err)
+		}
+	}
+	for _, table := range tables {
+		if table.Name == name {
+			return &table, nil
+		}
+	}
+	return nil, nil
+}
+
+// alterTable runs a single alterTable operation (add, drop, addVectorize or dropVectorize) on a table.
+func (c *dataAPIClient) alterTable(ctx context.Context, token, keyspace, name, operation string, columns interface{}) error {
+	_, err := c.runCommand(ctx, token, keyspace+"/"+name, "alterTable", map[string]interface{}{
+		"operation": map[string]interface{}{
+			operation: map[string]interface{}{"columns": columns},
+		},
+	})
+	return err
+}
+
+func (c *dataAPIClient) dropTable(ctx context.Context, token, keyspace, name string) error {
+	_, err := c.runCommand(ctx, token, keyspace, "dropTable", map[string]string{"name": name})
+	return err
+}
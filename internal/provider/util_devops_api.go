@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+)
+
+// devopsRequest sends a request for the given path, relative to the DevOps API root, using the transport and request
+// editors of the given client. It is used for endpoints which are not modelled by the generated client. The body, if not
+// nil, is sent as JSON.
+func devopsRequest(ctx context.Context, astraClient *astra.ClientWithResponses, method, path string, body interface{}) ([]byte, int, error) {
+	client, ok := astraClient.ClientInterface.(*astra.Client)
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected Astra client type %T", astraClient.ClientInterface)
+	}
+	editors := make([]requestEditor, 0, len(client.RequestEditors))
+	for _, editor := range client.RequestEditors {
+		editors = append(editors, requestEditor(editor))
+	}
+	return jsonRequest(ctx, client.Client, client.Server, editors, method, path, body)
+}
+
+type requestEditor func(ctx context.Context, req *http.Request) error
+
+type httpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// jsonRequest sends a request for the given path, relative to the server URL, and returns the response body and status code.
+// The body, if not nil, is sent as JSON.
+func jsonRequest(ctx context.Context, doer httpRequestDoer, server string, editors []requestEditor, method, path string, body interface{}) ([]byte, int, error) {
+	if body == nil {
+		return rawRequest(ctx, doer, server, editors, method, path, "", nil)
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return rawRequest(ctx, doer, server, editors, method, path, "application/json", bytes.NewReader(payload))
+}
+
+// rawRequest sends a request for the given path, relative to the server URL, and returns the response body and status code.
+// The body, if not nil, is sent with the given content type.
+func rawRequest(ctx context.Context, doer httpRequestDoer, server string, editors []requestEditor, method, path, contentType string, body io.Reader) ([]byte, int, error) {
+	reqURL := strings.TrimSuffix(server, "/") + "/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for _, editor := range editors {
+		if err := editor(ctx, req); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	return respBody, resp.StatusCode, nil
+}
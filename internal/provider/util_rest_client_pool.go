@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	astrarestapi "github.com/datastax/astra-client-go/v2/astra-rest-api"
+)
+
+// restClientPool manages the per-database Stargate REST and Data API clients used by the schema level resources (keyspaces,
+// tables, collections, ...). Clients are keyed by database ID and requested region, and are reused across resources so that
+// connections are shared. When the requested region does not have a healthy datacenter, the pool falls back to another
+// region of the database. The region of a cached client is resolved again once it is older than regionTTL, so that a
+// client does not stay pinned to a datacenter which became unavailable. The clients do not hold the token, which is set
+// on each request by the callers, so there is nothing to refresh when the token changes.
+type restClientPool struct {
+	astraClient     *astra.ClientWithResponses
+	providerVersion string
+	userAgent       string
+	regionTTL       time.Duration
+
+	mu             sync.Mutex
+	clients        map[string]restClientPoolEntry
+	dataAPIClients map[string]dataAPIClientPoolEntry
+}
+
+// restClientPoolRegionTTL is how long the region of a cached client is used before the datacenters of the database are
+// checked again.
+const restClientPoolRegionTTL = 5 * time.Minute
+
+type dataAPIClientPoolEntry struct {
+	client     *dataAPIClient
+	region     string
+	resolvedAt time.Time
+}
+
+type restClientPoolEntry struct {
+	client     *astrarestapi.ClientWithResponses
+	region     string
+	resolvedAt time.Time
+}
+
+// fresh returns true if the region of the entry was resolved recently enough to be reused without checking it again.
+func (e restClientPoolEntry) fresh(ttl time.Duration) bool {
+	return time.Since(e.resolvedAt) < ttl
+}
+
+func (e dataAPIClientPoolEntry) fresh(ttl time.Duration) bool {
+	return time.Since(e.resolvedAt) < ttl
+}
+
+func newRestClientPool(astraClient *astra.ClientWithResponses, providerVersion, userAgent string) *restClientPool {
+	return &restClientPool{
+		astraClient:     astraClient,
+		providerVersion: providerVersion,
+		userAgent:       userAgent,
+		regionTTL:       restClientPoolRegionTTL,
+		clients:         map[string]restClientPoolEntry{},
+		dataAPIClients:  map[string]dataAPIClientPoolEntry{},
+	}
+}
+
+func restClientPoolKey(databaseID, region string) string {
+	return databaseID + "/" + strings.ToLower(region)
+}
+
+// get returns a REST client for the given database, preferring the given region. The region may be empty, in which
+// case any healthy datacenter of the database is used. The region the client actually connects to is also returned.
+func (p *restClientPool) get(ctx context.Context, databaseID, region string) (*astrarestapi.ClientWithResponses, string, error) {
+	key := restClientPoolKey(databaseID, region)
+
+	p.mu.Lock()
+	entry, ok := p.clients[key]
+	p.mu.Unlock()
+	if ok && entry.fresh(p.regionTTL) {
+		return entry.client, entry.region, nil
+	}
+
+	resolvedRegion, err := p.resolveRegion(ctx, databaseID, region)
+	if err != nil {
+		return nil, "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Another resource may have created a client for this region in the meantime, or the region did not change and the
+	// existing client is kept so that its connections are reused. The entry of a requested region points to another region
+	// when the requested region was unhealthy, it is replaced once the requested region is healthy again.
+	resolvedKey := restClientPoolKey(databaseID, resolvedRegion)
+	entry, ok = p.clients[resolvedKey]
+	if !ok || !strings.EqualFold(entry.region, resolvedRegion) {
+		client, err := newRestClient(databaseID, p.providerVersion, p.userAgent, resolvedRegion)
+		if err != nil {
+			return nil, "", err
+		}
+		entry = restClientPoolEntry{client: client, region: resolvedRegion}
+	}
+	if !entry.fresh(p.regionTTL) {
+		entry.resolvedAt = time.Now()
+	}
+	p.clients[key] = entry
+	p.clients[resolvedKey] = entry
+	return entry.client, entry.region, nil
+}
+
+// getDataAPI returns a Data API client for the given database, preferring the given region. It follows the same rules as get.
+func (p *restClientPool) getDataAPI(ctx context.Context, databaseID, region string) (*dataAPIClient, string, error) {
+	key := restClientPoolKey(databaseID, region)
+
+	p.mu.Lock()
+	entry, ok := p.dataAPIClients[key]
+	p.mu.Unlock()
+	if ok && entry.fresh(p.regionTTL) {
+		return entry.client, entry.region, nil
+	}
+
+	resolvedRegion, err := p.resolveRegion(ctx, databaseID, region)
+	if err != nil {
+		return nil, "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	resolvedKey := restClientPoolKey(databaseID, resolvedRegion)
+	entry, ok = p.dataAPIClients[resolvedKey]
+	if !ok || !strings.EqualFold(entry.region, resolvedRegion) {
+		entry = dataAPIClientPoolEntry{
+			client: newDataAPIClient(databaseID, p.providerVersion, p.userAgent, resolvedRegion),
+			region: resolvedRegion,
+		}
+	}
+	if !entry.fresh(p.regionTTL) {
+		entry.resolvedAt = time.Now()
+	}
+	p.dataAPIClients[key] = entry
+	p.dataAPIClients[resolvedKey] = entry
+	return entry.client, entry.region, nil
+}
+
+// evict removes all cached clients of a database, so that the next call to get picks a healthy datacenter again.  It should
+// be called when a request fails in a way that suggests the datacenter is unavailable or the client is no longer valid.
+func (p *restClientPool) evict(databaseID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key := range p.clients {
+		if strings.HasPrefix(key, databaseID+"/") {
+			delete(p.clients, key)
+		}
+	}
+	for key := range p.dataAPIClients {
+		if strings.HasPrefix(key, databaseID+"/") {
+			delete(p.dataAPIClients, key)
+		}
+	}
+}
+
+func (p *restClientPool) resolveRegion(ctx context.Context, databaseID, region string) (string, error) {
+	if p.astraClient == nil {
+		if region == "" {
+			return "", fmt.Errorf("unable to determine a region for database %s", databaseID)
+		}
+		return region, nil
+	}
+	resp, err := p.astraClient.GetDatabaseWithResponse(ctx, astra.DatabaseIdParam(databaseID))
+	if err != nil {
+		return "", fmt.Errorf("error fetching database %s: %w", databaseID, err)
+	} else if resp.StatusCode() >= 400 || resp.JSON200 == nil {
+		return "", fmt.Errorf("error fetching database %s, status code: %d, message: %s", databaseID, resp.StatusCode(), string(resp.Body))
+	}
+	var datacenters []astra.Datacenter
+	if resp.JSON200.Info.Datacenters != nil {
+		datacenters = *resp.JSON200.Info.Datacenters
+	}
+	return selectDatacenterRegion(databaseID, datacenters, region)
+}
+
+// selectDatacenterRegion picks the region of the datacenter to connect to. The preferred region is used if it has a
+// healthy datacenter, otherwise the first healthy region in alphabetical order is used. If no datacenter is healthy,
+// the preferred region is returned as is so that the caller gets a meaningful error from the API.
+func selectDatacenterRegion(databaseID string, datacenters []astra.Datacenter, preferredRegion string) (string, error) {
+	healthy := []string{}
+	for _, dc := range datacenters {
+		if dc.Status == "" || strings.EqualFold(dc.Status, string(astra.ACTIVE)) {
+			healthy = append(healthy, dc.Region)
+		}
+	}
+	sort.Strings(healthy)
+
+	for _, r := range healthy {
+		if strings.EqualFold(r, preferredRegion) {
+			return r, nil
+		}
+	}
+	if len(healthy) > 0 {
+		return healthy[0], nil
+	}
+	if preferredRegion != "" {
+		return preferredRegion, nil
+	}
+	if len(datacenters) > 0 {
+		return datacenters[0].Region, nil
+	}
+	return "", fmt.Errorf("no datacenters found for database %s", databaseID)
+}
+
+// restGet sends a GET request for the given path, relative to the REST API root, using the transport and request editors of
+// the given client. It is used for endpoints which are not modelled, or not modelled correctly, by the generated client.
+func restGet(ctx context.Context, restClient *astrarestapi.ClientWithResponses, token, path string, query url.Values) ([]byte, int, error) {
+	client, ok := restClient.ClientInterface.(*astrarestapi.Client)
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected REST client type %T", restClient.ClientInterface)
+	}
+	reqURL := strings.TrimSuffix(client.Server, "/") + "/" + strings.TrimPrefix(path, "/")
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("X-Cassandra-Token", token)
+	for _, editor := range client.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return nil, 0, err
+		}
+	}
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	return body, resp.StatusCode, nil
+}
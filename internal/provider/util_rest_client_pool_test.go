@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/datastax/astra-client-go/v2/astra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectDatacenterRegion(t *testing.T) {
+	datacenters := []astra.Datacenter{
+		{Region: "us-east1", Status: "ACTIVE"},
+		{Region: "us-west1", Status: "HIBERNATED"},
+		{Region: "europe-west1", Status: "ACTIVE"},
+	}
+
+	tests := []struct {
+		name        string
+		datacenters []astra.Datacenter
+		preferred   string
+		expected    string
+		expectError bool
+	}{
+		{name: "preferred region healthy", datacenters: datacenters, preferred: "us-east1", expected: "us-east1"},
+		{name: "preferred region case insensitive", datacenters: datacenters, preferred: "US-EAST1", expected: "us-east1"},
+		{name: "preferred region unhealthy", datacenters: datacenters, preferred: "us-west1", expected: "europe-west1"},
+		{name: "preferred region unknown", datacenters: datacenters, preferred: "ap-south1", expected: "europe-west1"},
+		{name: "no preferred region", datacenters: datacenters, preferred: "", expected: "europe-west1"},
+		{name: "no healthy datacenters", datacenters: []astra.Datacenter{{Region: "us-west1", Status: "HIBERNATED"}}, preferred: "us-east1", expected: "us-east1"},
+		{name: "no healthy datacenters and no preferred region", datacenters: []astra.Datacenter{{Region: "us-west1", Status: "HIBERNATED"}}, preferred: "", expected: "us-west1"},
+		{name: "no datacenters", datacenters: nil, preferred: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region, err := selectDatacenterRegion("db", tt.datacenters, tt.preferred)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, region)
+		})
+	}
+}
+
+func TestRestClientPoolRevalidatesRegion(t *testing.T) {
+	primaryStatus := "ACTIVE"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id": "db", "status": "ACTIVE", "info": {"datacenters": [{"region": "us-east1", "status": %q}, {"region": "us-west1", "status": "ACTIVE"}]}}`, primaryStatus)
+	}))
+	defer server.Close()
+
+	astraClient, err := astra.NewClientWithResponses(server.URL)
+	assert.NoError(t, err)
+	pool := newRestClientPool(astraClient, "test", "test")
+	ctx := context.Background()
+
+	client, region, err := pool.get(ctx, "db", "us-east1")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east1", region)
+
+	// the cached region is reused until it expires
+	primaryStatus = "HIBERNATED"
+	cached, region, err := pool.get(ctx, "db", "us-east1")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east1", region)
+	assert.Same(t, client, cached)
+
+	// the client of a region which is still healthy is kept
+	pool.regionTTL = 0
+	primaryStatus = "ACTIVE"
+	revalidated, region, err := pool.get(ctx, "db", "us-east1")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east1", region)
+	assert.Same(t, client, revalidated)
+
+	// an expired region which became unhealthy falls back to another region, and back again once it is healthy
+	primaryStatus = "HIBERNATED"
+	_, region, err = pool.get(ctx, "db", "us-east1")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west1", region)
+
+	primaryStatus = "ACTIVE"
+	_, region, err = pool.get(ctx, "db", "us-east1")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east1", region)
+}
@@ -8,9 +8,12 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/datastax/astra-client-go/v2/astra"
 	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/pulsar-admin-client-go/src/pulsaradmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 const (
@@ -184,3 +187,179 @@ func int32ToInt64Pointer(v *int32) *int64 {
 	x := int64(*v)
 	return &x
 }
+
+// streamingRequest sends a request for the given path, relative to the Astra Streaming API root, to the given Pulsar
+// cluster. It is used for endpoints which are not modelled by the generated client. The body, if not nil, is sent as JSON.
+func streamingRequest(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, cluster, method, path string, body interface{}) ([]byte, int, error) {
+	client, ok := streamingClient.ClientInterface.(*astrastreaming.Client)
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected Astra Streaming client type %T", streamingClient.ClientInterface)
+	}
+	editors := make([]requestEditor, 0, len(client.RequestEditors)+1)
+	for _, editor := range client.RequestEditors {
+		editors = append(editors, requestEditor(editor))
+	}
+	editors = append(editors, setPulsarClusterHeaders(cluster))
+	return jsonRequest(ctx, client.Client, client.Server, editors, method, path, body)
+}
+
+// pulsarAdminRequest sends a request for the given path, relative to the Pulsar admin API root (e.g. "v3/functions/..."),
+// to the given Pulsar cluster. It is used for the Pulsar admin endpoints which are not modelled by the generated client,
+// which only covers the "/admin/v2" API. The body, if not nil, is sent with the given content type.
+func pulsarAdminRequest(ctx context.Context, pulsarAdminClient *pulsaradmin.ClientWithResponses, cluster, method, path, contentType string, body io.Reader) ([]byte, int, error) {
+	client, ok := pulsarAdminClient.ClientInterface.(*pulsaradmin.Client)
+	if !ok {
+		return nil, 0, fmt.Errorf("unexpected Pulsar admin client type %T", pulsarAdminClient.ClientInterface)
+	}
+	editors := make([]requestEditor, 0, len(client.RequestEditors)+1)
+	for _, editor := range client.RequestEditors {
+		editors = append(editors, requestEditor(editor))
+	}
+	editors = append(editors, setPulsarClusterHeaders(cluster))
+	server := strings.TrimSuffix(strings.TrimSuffix(client.Server, "/"), "/v2")
+	return rawRequest(ctx, client.Client, server, editors, method, path, contentType, body)
+}
+
+// pulsarConnectorStatus is the status of a Pulsar sink, source or function, as returned by the Pulsar functions worker.
+type pulsarConnectorStatus struct {
+	NumInstances int                             `json:"numInstances"`
+	NumRunning   int                             `json:"numRunning"`
+	Instances    []pulsarConnectorInstanceStatus `json:"instances"`
+}
+
+type pulsarConnectorInstanceStatus struct {
+	InstanceID int `json:"instanceId"`
+	Status     struct {
+		Running                bool                         `json:"running"`
+		Error                  string                       `json:"error"`
+		NumRestarts            int64                        `json:"numRestarts"`
+		NumReceived            int64                        `json:"numReceived"`
+		WorkerID               string                       `json:"workerId"`
+		LatestSystemExceptions []pulsarExceptionInformation `json:"latestSystemExceptions"`
+		LatestSinkExceptions   []pulsarExceptionInformation `json:"latestSinkExceptions"`
+		LatestSourceExceptions []pulsarExceptionInformation `json:"latestSourceExceptions"`
+		LatestUserExceptions   []pulsarExceptionInformation `json:"latestUserExceptions"`
+	} `json:"status"`
+}
+
+type pulsarExceptionInformation struct {
+	ExceptionString string `json:"exceptionString"`
+	TimestampMs     int64  `json:"timestampMs"`
+}
+
+// running returns true if all the instances are running.
+func (s *pulsarConnectorStatus) running() bool {
+	return s.NumInstances > 0 && s.NumRunning >= s.NumInstances
+}
+
+// restartedSince returns true if the instances were restarted since the previous status: they aren't all running, their
+// number changed, or an instance was rescheduled, restarted or its counters were reset.
+func (s *pulsarConnectorStatus) restartedSince(previous *pulsarConnectorStatus) bool {
+	if !s.running() || s.NumInstances != previous.NumInstances {
+		return true
+	}
+	previousInstances := make(map[int]pulsarConnectorInstanceStatus, len(previous.Instances))
+	for _, instance := range previous.Instances {
+		previousInstances[instance.InstanceID] = instance
+	}
+	for _, instance := range s.Instances {
+		previousInstance, ok := previousInstances[instance.InstanceID]
+		if !ok || instance.Status.WorkerID != previousInstance.Status.WorkerID ||
+			instance.Status.NumRestarts != previousInstance.Status.NumRestarts ||
+			instance.Status.NumReceived < previousInstance.Status.NumReceived {
+			return true
+		}
+	}
+	return false
+}
+
+// numRestarts returns the total number of restarts of the instances.
+func (s *pulsarConnectorStatus) numRestarts() int64 {
+	var restarts int64
+	for _, instance := range s.Instances {
+		restarts += instance.Status.NumRestarts
+	}
+	return restarts
+}
+
+// latestError returns the error of the first failed instance or, if no instance failed, the most recent exception
+// reported by an instance. It returns an empty string if there is neither.
+func (s *pulsarConnectorStatus) latestError() string {
+	for _, instance := range s.Instances {
+		if instance.Status.Error != "" {
+			return fmt.Sprintf("instance %d: %s", instance.InstanceID, instance.Status.Error)
+		}
+	}
+	latest, latestInstance := pulsarExceptionInformation{}, 0
+	for _, instance := range s.Instances {
+		for _, exceptions := range [][]pulsarExceptionInformation{
+			instance.Status.LatestSystemExceptions,
+			instance.Status.LatestSinkExceptions,
+			instance.Status.LatestSourceExceptions,
+			instance.Status.LatestUserExceptions,
+		} {
+			for _, exception := range exceptions {
+				if exception.ExceptionString != "" && exception.TimestampMs >= latest.TimestampMs {
+					latest, latestInstance = exception, instance.InstanceID
+				}
+			}
+		}
+	}
+	if latest.ExceptionString == "" {
+		return ""
+	}
+	return fmt.Sprintf("instance %d: %s", latestInstance, latest.ExceptionString)
+}
+
+// getPulsarConnectorStatus returns the status of a Pulsar sink, source or function. The kind is "sinks", "sources" or "functions".
+func getPulsarConnectorStatus(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, cluster, kind, tenant, namespace, name string) (*pulsarConnectorStatus, error) {
+	path := fmt.Sprintf("admin/v3/%s/%s/%s/%s/status", kind, tenant, namespace, name)
+	body, statusCode, err := streamingRequest(ctx, streamingClient, cluster, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	} else if statusCode >= 300 {
+		return nil, fmt.Errorf("failed to get status of %s/%s/%s, status code: %d, message: %s", tenant, namespace, name, statusCode, string(body))
+	}
+	var status pulsarConnectorStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal status of %s/%s/%s: %w", tenant, namespace, name, err)
+	}
+	return &status, nil
+}
+
+// pulsarConnectorRestartGracePeriod is how long to wait for the instances of an updated Pulsar sink, source or function
+// to be restarted. An idle instance restarted on the same worker between two status requests can't be told apart from
+// the previous one, so after this period the instances are assumed to have been restarted.
+const pulsarConnectorRestartGracePeriod = 30 * time.Second
+
+// waitForPulsarConnectorRunning waits until the expected number of instances of a Pulsar sink, source or function are
+// running. After an update, previous is the status before the update, and the instances of the previous deployment are
+// not considered running until they have been restarted. The last retrieved status is returned, even if the instances
+// are not running before the timeout.
+func waitForPulsarConnectorRunning(ctx context.Context, streamingClient *astrastreaming.ClientWithResponses, cluster, kind, tenant, namespace, name string,
+	parallelism int, previous *pulsarConnectorStatus, timeout time.Duration) (*pulsarConnectorStatus, error) {
+	var status *pulsarConnectorStatus
+	start := time.Now()
+	restarted := previous == nil
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		current, err := getPulsarConnectorStatus(ctx, streamingClient, cluster, kind, tenant, namespace, name)
+		if err != nil {
+			// the status is not available until the instances are scheduled
+			return retry.RetryableError(err)
+		}
+		status = current
+		restarted = restarted || status.restartedSince(previous) || time.Since(start) > pulsarConnectorRestartGracePeriod
+		if !restarted {
+			return retry.RetryableError(fmt.Errorf("waiting for the instances of %s/%s/%s to be restarted", tenant, namespace, name))
+		}
+		if !status.running() || (parallelism > 0 && status.NumInstances != parallelism) {
+			message := fmt.Sprintf("%d of %d instances of %s/%s/%s running", status.NumRunning, max(parallelism, status.NumInstances), tenant, namespace, name)
+			if latestError := status.latestError(); latestError != "" {
+				message += ", latest error: " + latestError
+			}
+			return retry.RetryableError(errors.New(message))
+		}
+		return nil
+	})
+	return status, err
+}
@@ -1,8 +1,16 @@
 package provider
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	astrastreaming "github.com/datastax/astra-client-go/v2/astra-streaming"
+	"github.com/datastax/pulsar-admin-client-go/src/pulsaradmin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,3 +45,134 @@ func TestGetProviderRegionFromClusterName(t *testing.T) {
 	assert.Equal(t, "azure", provider)
 	assert.Equal(t, "centralus", region)
 }
+
+func TestPulsarConnectorStatus(t *testing.T) {
+	status := &pulsarConnectorStatus{}
+	assert.False(t, status.running())
+	assert.Equal(t, "", status.latestError())
+
+	status = &pulsarConnectorStatus{
+		NumInstances: 2,
+		NumRunning:   1,
+		Instances:    make([]pulsarConnectorInstanceStatus, 2),
+	}
+	status.Instances[0].InstanceID = 0
+	status.Instances[0].Status.Running = true
+	status.Instances[1].InstanceID = 1
+	status.Instances[1].Status.Error = "connection refused"
+	assert.False(t, status.running())
+	assert.Equal(t, "instance 1: connection refused", status.latestError())
+
+	status.NumRunning = 2
+	assert.True(t, status.running())
+}
+
+func TestPulsarConnectorStatusLatestException(t *testing.T) {
+	status := &pulsarConnectorStatus{
+		NumInstances: 2,
+		NumRunning:   2,
+		Instances:    make([]pulsarConnectorInstanceStatus, 2),
+	}
+	status.Instances[0].Status.NumRestarts = 2
+	status.Instances[0].Status.LatestSinkExceptions = []pulsarExceptionInformation{
+		{ExceptionString: "table not found", TimestampMs: 1000},
+	}
+	status.Instances[1].InstanceID = 1
+	status.Instances[1].Status.NumRestarts = 1
+	status.Instances[1].Status.LatestSystemExceptions = []pulsarExceptionInformation{
+		{ExceptionString: "connection reset", TimestampMs: 2000},
+	}
+	assert.Equal(t, int64(3), status.numRestarts())
+	assert.Equal(t, "instance 1: connection reset", status.latestError())
+}
+
+func TestPulsarConnectorStatusRestartedSince(t *testing.T) {
+	newStatus := func(workerID string, numReceived int64) *pulsarConnectorStatus {
+		status := &pulsarConnectorStatus{
+			NumInstances: 1,
+			NumRunning:   1,
+			Instances:    make([]pulsarConnectorInstanceStatus, 1),
+		}
+		status.Instances[0].Status.Running = true
+		status.Instances[0].Status.WorkerID = workerID
+		status.Instances[0].Status.NumReceived = numReceived
+		return status
+	}
+	previous := newStatus("worker-1", 100)
+
+	assert.False(t, newStatus("worker-1", 100).restartedSince(previous))
+	assert.False(t, newStatus("worker-1", 120).restartedSince(previous))
+	assert.True(t, newStatus("worker-1", 0).restartedSince(previous))
+	assert.True(t, newStatus("worker-2", 100).restartedSince(previous))
+
+	restarted := newStatus("worker-1", 100)
+	restarted.Instances[0].Status.NumRestarts = 1
+	assert.True(t, restarted.restartedSince(previous))
+
+	stopped := newStatus("worker-1", 100)
+	stopped.NumRunning = 0
+	assert.True(t, stopped.restartedSince(previous))
+}
+
+func TestWaitForPulsarConnectorRunningAfterUpdate(t *testing.T) {
+	statuses := []string{
+		// the instance of the previous deployment is still running
+		`{"numInstances": 1, "numRunning": 1, "instances": [{"instanceId": 0, "status": {"running": true, "workerId": "worker-1", "numReceived": 100}}]}`,
+		`{"numInstances": 2, "numRunning": 1, "instances": [{"instanceId": 0, "status": {"running": true, "workerId": "worker-1"}}, {"instanceId": 1, "status": {"running": false, "workerId": "worker-2"}}]}`,
+		`{"numInstances": 2, "numRunning": 2, "instances": [{"instanceId": 0, "status": {"running": true, "workerId": "worker-1"}}, {"instanceId": 1, "status": {"running": true, "workerId": "worker-2"}}]}`,
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/admin/v3/sinks/my-tenant/default/my-sink/status", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(statuses[min(requests, len(statuses)-1)]))
+		requests++
+	}))
+	defer server.Close()
+
+	client, err := astrastreaming.NewClientWithResponses(server.URL)
+	assert.Nil(t, err)
+
+	previous := &pulsarConnectorStatus{
+		NumInstances: 1,
+		NumRunning:   1,
+		Instances:    make([]pulsarConnectorInstanceStatus, 1),
+	}
+	previous.Instances[0].Status.Running = true
+	previous.Instances[0].Status.WorkerID = "worker-1"
+	previous.Instances[0].Status.NumReceived = 100
+
+	status, err := waitForPulsarConnectorRunning(context.Background(), client, "pulsar-gcp-useast1", "sinks", "my-tenant", "default", "my-sink",
+		2, previous, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 2, status.NumRunning)
+}
+
+func TestPulsarAdminRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/admin/v3/functions/my-tenant/default/my-function", r.URL.Path)
+		assert.Equal(t, "pulsar-gcp-useast1", r.Header.Get(pulsarClusterHeader))
+		assert.Equal(t, "Bearer token", r.Header.Get(authHeader))
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "body", string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := pulsaradmin.NewClientWithResponses(server.URL+"/admin/v2", func(c *pulsaradmin.Client) error {
+		c.RequestEditors = append(c.RequestEditors, func(ctx context.Context, req *http.Request) error {
+			req.Header.Set(authHeader, "Bearer token")
+			return nil
+		})
+		return nil
+	})
+	assert.Nil(t, err)
+
+	_, statusCode, err := pulsarAdminRequest(context.Background(), client, "pulsar-gcp-useast1", http.MethodPut,
+		functionPath("my-tenant", "default", "my-function"), "text/plain", strings.NewReader("body"))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode)
+}
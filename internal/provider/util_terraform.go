@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,6 +25,22 @@ func ElvisTF[T attr.Value](val *T, orElse T) T {
 	return *val
 }
 
+// knownInt64Pointer returns a pointer to the value, or nil if the value is null or unknown.
+func knownInt64Pointer(val types.Int64) *int64 {
+	if val.IsNull() || val.IsUnknown() {
+		return nil
+	}
+	return val.ValueInt64Pointer()
+}
+
+// stringValueOrNull returns a null value for an empty string.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
 // UpdateTerraformObjectWithAttr adds an Attribute to a Terraform object
 func UpdateTerraformObjectWithAttr(ctx context.Context, obj types.Object, key string, value attr.Value) (types.Object, diag.Diagnostics) {
 	attrTypes := obj.AttributeTypes(ctx)
@@ -295,3 +312,29 @@ func planModifierSuppressDashesDiff() planmodifier.String {
 		}
 	})
 }
+
+// planModifierSuppressJSONDiff keeps the state value if the configured JSON string is semantically equal to it, ignoring
+// formatting and key order.
+func planModifierSuppressJSONDiff() planmodifier.String {
+	return MkStringPlanModifier("Suppress diffs if old and new JSON values are semantically equal", func(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+		if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.StateValue.IsNull() {
+			return
+		}
+
+		if jsonEquivalent(req.StateValue.ValueString(), req.ConfigValue.ValueString()) {
+			resp.PlanValue = req.StateValue
+		}
+	})
+}
+
+// jsonEquivalent returns true if both strings are valid and semantically equal JSON documents.
+func jsonEquivalent(a, b string) bool {
+	var aValue, bValue interface{}
+	if err := json.Unmarshal([]byte(a), &aValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
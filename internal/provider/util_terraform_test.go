@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMergeTerraformObjects(t *testing.T) {
@@ -49,3 +50,10 @@ func assertNoDiags(diags diag.Diagnostics) {
 		log.Fatalf("unexpected diagnotics: %v", diags)
 	}
 }
+
+func TestJSONEquivalent(t *testing.T) {
+	assert.True(t, jsonEquivalent(`{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`))
+	assert.False(t, jsonEquivalent(`{"a": 1}`, `{"a": "1"}`))
+	assert.False(t, jsonEquivalent(`{"b": [1, 2]}`, `{"b": [2, 1]}`))
+	assert.False(t, jsonEquivalent(`{"a": 1}`, `not json`))
+}
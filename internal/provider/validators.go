@@ -16,6 +16,11 @@ import (
 )
 
 var keyspaceNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_]{0,48}$`)
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+var collectionNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,47}$`)
+var awsKMSKeyARNRegex = regexp.MustCompile(`^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/[a-zA-Z0-9-]+$`)
+var gcpKMSKeyNameRegex = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+(/cryptoKeyVersions/[^/]+)?$`)
+var azureKeyVaultURIRegex = regexp.MustCompile(`^https://[a-zA-Z][a-zA-Z0-9-]{1,22}[a-zA-Z0-9]\.vault\.azure\.net/?$`)
 var roleResourcePrefix = "drn:astra:org:"
 
 func validateKeyspace(v interface{}, path cty.Path) diag.Diagnostics {